@@ -1,16 +1,18 @@
 package beautiful
 
 import (
-	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
-	"runtime"
+	"os/signal"
+	"regexp"
 	"strconv"
 	"strings"
 	"syscall"
-	"unsafe"
+	"unicode/utf8"
+
+	"gfcli/i18n"
 
 	"github.com/fatih/color"
 )
@@ -32,175 +34,374 @@ type JSONExplorer struct {
 	cursor   *JSONNode
 	output   *Output
 	terminal *Terminal
-}
+	input    *os.File
 
-// Terminal gerencia operações do terminal
-type Terminal struct {
-	originalState *TerminalState
-}
+	// offset é o índice do primeiro nó visível na tela
+	offset int
+
+	// Estado da busca
+	searching bool
+	query     string
+	matches   []*JSONNode
+	match     int
 
-// TerminalState armazena o estado original do terminal
-type TerminalState struct {
-	termios syscall.Termios
+	// selected é a subárvore a ser impressa ao sair
+	selected *JSONNode
 }
 
+// Linhas reservadas para cabeçalho, caminho, instruções e busca
+const explorerReservedLines = 9
+
+var jsonPathIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
 // NewJSONExplorer cria uma nova instância do explorador JSON
 func NewJSONExplorer(output *Output) *JSONExplorer {
 	return &JSONExplorer{
 		output:   output,
-		terminal: &Terminal{},
+		terminal: &Terminal{fd: int(os.Stdin.Fd())},
+		input:    os.Stdin,
 	}
 }
 
+// SetInput define de onde o teclado é lido. Útil quando o documento
+// explorado chega por stdin e as teclas precisam vir de /dev/tty.
+func (je *JSONExplorer) SetInput(input *os.File) {
+	je.input = input
+	je.terminal.fd = int(input.Fd())
+}
+
 // ExploreJSON inicia a exploração interativa de um JSON
 func (je *JSONExplorer) ExploreJSON(data []byte) error {
-	// Parse do JSON
-	var jsonData interface{}
-	if err := json.Unmarshal(data, &jsonData); err != nil {
-		return fmt.Errorf("erro ao fazer parse do JSON: %v", err)
+	// Parse do JSON preservando a ordem das chaves
+	manager := i18n.GetInstance()
+	root, err := parseJSONTree(data)
+	if err != nil {
+		return fmt.Errorf(manager.T("explorer.parse_error"), err)
 	}
 
-	// Construir árvore de nós
-	je.root = je.buildNode("root", jsonData, 0)
+	je.root = root
+	je.root.IsExpanded = true
 	je.cursor = je.root
 
 	// Configurar terminal
 	if err := je.terminal.setRawMode(); err != nil {
-		return fmt.Errorf("erro ao configurar terminal: %v", err)
+		return fmt.Errorf(manager.T("explorer.terminal_error"), err)
 	}
-	defer je.terminal.restoreMode()
 
 	// Iniciar loop de navegação
-	return je.navigationLoop()
+	loopErr := je.navigationLoop()
+	je.terminal.restoreMode()
+	fmt.Println()
+	if loopErr != nil {
+		return loopErr
+	}
+
+	if je.selected != nil {
+		return je.printSubtree(je.selected)
+	}
+	return nil
+}
+
+// parseJSONTree constrói a árvore de nós a partir dos tokens do JSON,
+// mantendo as chaves dos objetos na ordem em que aparecem no documento
+func parseJSONTree(data []byte) (*JSONNode, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decodeNode(decoder, "root", 0)
 }
 
-// buildNode constrói um nó da árvore JSON
-func (je *JSONExplorer) buildNode(key string, value interface{}, level int) *JSONNode {
+// decodeNode lê o próximo valor do decoder como um nó da árvore
+func decodeNode(decoder *json.Decoder, key string, level int) (*JSONNode, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
 	node := &JSONNode{
-		Key:        key,
-		Value:      value,
-		Level:      level,
-		IsExpanded: false,
-		Children:   []*JSONNode{},
-	}
-
-	switch v := value.(type) {
-	case map[string]interface{}:
-		node.Type = "object"
-		for k, val := range v {
-			child := je.buildNode(k, val, level+1)
-			child.Parent = node
-			node.Children = append(node.Children, child)
+		Key:      key,
+		Value:    token,
+		Level:    level,
+		Children: []*JSONNode{},
+	}
+
+	switch v := token.(type) {
+	case json.Delim:
+		node.Value = nil
+		switch v {
+		case '{':
+			node.Type = "object"
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				child, err := decodeNode(decoder, fmt.Sprint(keyToken), level+1)
+				if err != nil {
+					return nil, err
+				}
+				child.Parent = node
+				node.Children = append(node.Children, child)
+			}
+		case '[':
+			node.Type = "array"
+			for i := 0; decoder.More(); i++ {
+				child, err := decodeNode(decoder, strconv.Itoa(i), level+1)
+				if err != nil {
+					return nil, err
+				}
+				child.Parent = node
+				node.Children = append(node.Children, child)
+			}
+		default:
+			return nil, fmt.Errorf(i18n.GetInstance().T("explorer.unexpected_delimiter"), v)
 		}
-	case []interface{}:
-		node.Type = "array"
-		for i, val := range v {
-			child := je.buildNode(strconv.Itoa(i), val, level+1)
-			child.Parent = node
-			node.Children = append(node.Children, child)
+		// Consumir o delimitador de fechamento
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
 		}
 	case string:
 		node.Type = "string"
-		node.Value = fmt.Sprintf("\"%s\"", v)
-	case float64:
+	case json.Number:
 		node.Type = "number"
-		node.Value = v
 	case bool:
 		node.Type = "boolean"
-		node.Value = v
 	case nil:
 		node.Type = "null"
-		node.Value = "null"
 	default:
 		node.Type = "unknown"
-		node.Value = fmt.Sprintf("%v", v)
 	}
 
-	return node
+	return node, nil
+}
+
+// Path retorna o JSONPath do nó, ex: $.items[0].name
+func (n *JSONNode) Path() string {
+	if n.Parent == nil {
+		return "$"
+	}
+
+	parent := n.Parent.Path()
+	if n.Parent.Type == "array" {
+		return parent + "[" + n.Key + "]"
+	}
+	if jsonPathIdentifier.MatchString(n.Key) {
+		return parent + "." + n.Key
+	}
+	return parent + "['" + strings.ReplaceAll(n.Key, "'", `\'`) + "']"
+}
+
+// MarshalJSON serializa a subárvore do nó preservando a ordem das chaves
+func (n *JSONNode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := n.writeJSON(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeJSON escreve o nó e seus filhos como JSON compacto
+func (n *JSONNode) writeJSON(buf *bytes.Buffer) error {
+	switch n.Type {
+	case "object":
+		buf.WriteByte('{')
+		for i, child := range n.Children {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(child.Key)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := child.writeJSON(buf); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case "array":
+		buf.WriteByte('[')
+		for i, child := range n.Children {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := child.writeJSON(buf); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		value, err := json.Marshal(n.Value)
+		if err != nil {
+			return err
+		}
+		buf.Write(value)
+	}
+	return nil
 }
 
 // navigationLoop gerencia o loop principal de navegação
 func (je *JSONExplorer) navigationLoop() error {
-	for {
-		// Limpar tela
-		je.clearScreen()
+	keys := keyboard(je.input)
 
-		// Renderizar árvore
+	// Redesenhar quando o terminal for redimensionado
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
+	defer signal.Stop(resize)
+
+	for {
 		je.renderTree()
 
-		// Ler input do usuário
-		key := je.readKey()
-
-		switch key {
-		case "q", "Q":
-			return nil // Sair
-		case "up", "k":
-			je.moveUp()
-		case "down", "j":
-			je.moveDown()
-		case "left", "h":
-			je.moveLeft()
-		case "right", "l":
-			je.moveRight()
-		case "enter", " ":
-			je.toggleExpand()
-		case "home":
-			je.goToRoot()
-		case "end":
-			je.goToEnd()
+		select {
+		case <-resize:
+			continue
+		case key, ok := <-keys:
+			if !ok {
+				return nil // stdin encerrado
+			}
+			if je.searching {
+				je.handleSearchKey(key)
+				continue
+			}
+			if done := je.handleKey(key); done {
+				return nil
+			}
+		}
+	}
+}
+
+// handleKey trata uma tecla no modo de navegação e indica se deve sair
+func (je *JSONExplorer) handleKey(key string) bool {
+	_, height := terminalSize()
+	page := max(height-explorerReservedLines, 1)
+
+	switch key {
+	case "q", "Q", "ctrl+c":
+		return true // Sair
+	case "p", "P":
+		je.selected = je.cursor
+		return true // Sair imprimindo a subárvore
+	case "up", "k", "K":
+		je.moveBy(-1)
+	case "down", "j", "J":
+		je.moveBy(1)
+	case "pgup":
+		je.moveBy(-page)
+	case "pgdown":
+		je.moveBy(page)
+	case "left", "h", "H":
+		je.moveLeft()
+	case "right", "l", "L":
+		je.moveRight()
+	case "enter", " ":
+		je.toggleExpand()
+	case "home", "g":
+		je.goToRoot()
+	case "end", "G":
+		je.goToEnd()
+	case "e":
+		je.setExpanded(je.root, true)
+	case "c":
+		je.setExpanded(je.root, false)
+		je.root.IsExpanded = true
+		je.cursor = je.root
+	case "/":
+		je.searching = true
+		je.query = ""
+	case "n":
+		je.nextMatch(1)
+	case "N":
+		je.nextMatch(-1)
+	}
+	return false
+}
+
+// handleSearchKey trata uma tecla enquanto o usuário digita a busca
+func (je *JSONExplorer) handleSearchKey(key string) {
+	switch key {
+	case "enter":
+		je.searching = false
+		je.runSearch()
+	case "esc", "ctrl+c":
+		je.searching = false
+		je.query = ""
+	case "backspace":
+		if len(je.query) > 0 {
+			_, size := utf8.DecodeLastRuneInString(je.query)
+			je.query = je.query[:len(je.query)-size]
+		}
+	default:
+		if utf8.RuneCountInString(key) == 1 {
+			je.query += key
 		}
 	}
 }
 
 // renderTree renderiza a árvore JSON na tela
 func (je *JSONExplorer) renderTree() {
-	je.output.PrintHeader("Explorador JSON - Use as setas para navegar, Enter para expandir/colapsar, Q para sair")
-
-	// Obter altura da tela
-	_, height := je.getTerminalSize()
-	availableLines := height - 5 // Reservar espaço para cabeçalho e instruções
+	var frame strings.Builder
 
-	// Renderizar nós visíveis
-	visibleNodes := je.getVisibleNodes(availableLines)
+	width, height := terminalSize()
+	availableLines := max(height-explorerReservedLines, 1)
 
-	for i, node := range visibleNodes {
-		isCursor := (node == je.cursor)
-		je.renderNode(node, isCursor)
+	visibleNodes := je.getVisibleNodes()
+	je.scrollToCursor(visibleNodes, availableLines)
 
-		// Parar se atingiu o limite da tela
-		if i >= availableLines-1 {
-			break
-		}
+	end := min(je.offset+availableLines, len(visibleNodes))
+	for _, node := range visibleNodes[je.offset:end] {
+		frame.WriteString(je.renderNode(node, node == je.cursor, width))
+		frame.WriteString("\n")
+	}
+	for i := end - je.offset; i < availableLines; i++ {
+		frame.WriteString("\n")
 	}
 
+	clearScreen()
+	je.output.PrintHeader(truncate(i18n.GetInstance().T("explorer.header"), width-6))
+	fmt.Print(frame.String())
+
+	// Mostrar caminho e posição do nó selecionado
+	position := fmt.Sprintf("[%d/%d]", indexOf(visibleNodes, je.cursor)+1, len(visibleNodes))
+	pathColor := color.New(color.FgYellow)
+	pathColor.Println(truncate(fmt.Sprintf("%s %s", position, je.cursor.Path()), width))
+
 	// Mostrar instruções
-	je.showInstructions()
+	je.showInstructions(width)
 }
 
-// getVisibleNodes retorna os nós visíveis na tela
-func (je *JSONExplorer) getVisibleNodes(maxLines int) []*JSONNode {
+// getVisibleNodes retorna todos os nós visíveis, respeitando a expansão
+func (je *JSONExplorer) getVisibleNodes() []*JSONNode {
 	var visible []*JSONNode
-	je.collectVisibleNodes(je.root, &visible, maxLines)
+	je.collectVisibleNodes(je.root, &visible)
 	return visible
 }
 
 // collectVisibleNodes coleta nós visíveis recursivamente
-func (je *JSONExplorer) collectVisibleNodes(node *JSONNode, visible *[]*JSONNode, maxLines int) {
-	if len(*visible) >= maxLines {
-		return
-	}
-
+func (je *JSONExplorer) collectVisibleNodes(node *JSONNode, visible *[]*JSONNode) {
 	*visible = append(*visible, node)
 
 	if node.IsExpanded {
 		for _, child := range node.Children {
-			je.collectVisibleNodes(child, visible, maxLines)
+			je.collectVisibleNodes(child, visible)
 		}
 	}
 }
 
+// scrollToCursor ajusta o offset para que o cursor fique dentro da janela
+func (je *JSONExplorer) scrollToCursor(visible []*JSONNode, lines int) {
+	index := indexOf(visible, je.cursor)
+	if index < 0 {
+		index = 0
+	}
+
+	if index < je.offset {
+		je.offset = index
+	}
+	if index >= je.offset+lines {
+		je.offset = index - lines + 1
+	}
+	je.offset = max(min(je.offset, len(visible)-lines), 0)
+}
+
 // renderNode renderiza um nó individual
-func (je *JSONExplorer) renderNode(node *JSONNode, isCursor bool) {
+func (je *JSONExplorer) renderNode(node *JSONNode, isCursor bool, width int) string {
 	indent := strings.Repeat("  ", node.Level)
 
 	var prefix string
@@ -215,34 +416,34 @@ func (je *JSONExplorer) renderNode(node *JSONNode, isCursor bool) {
 	}
 
 	// Construir linha
-	line := fmt.Sprintf("%s%s %s", indent, prefix, je.formatNode(node))
+	line := truncate(fmt.Sprintf("%s%s %s", indent, prefix, je.formatNode(node)), width)
 
 	// Aplicar cores baseadas no tipo e cursor
 	if isCursor {
 		cursorColor := color.New(color.BgCyan, color.FgBlack, color.Bold)
-		fmt.Println(cursorColor.Sprint(line))
-	} else {
-		je.colorizeNode(node, line)
+		return cursorColor.Sprint(line)
+	}
+	if je.isMatch(node) {
+		matchColor := color.New(color.BgYellow, color.FgBlack)
+		return matchColor.Sprint(line)
 	}
+	return je.colorizeNode(node, line)
 }
 
 // formatNode formata um nó para exibição
 func (je *JSONExplorer) formatNode(node *JSONNode) string {
-	if node.Key == "root" {
-		return "JSON Root"
+	manager := i18n.GetInstance()
+	if node == je.root {
+		return manager.T("explorer.root")
 	}
 
 	switch node.Type {
 	case "object":
-		return fmt.Sprintf("%s: {object} (%d items)", node.Key, len(node.Children))
+		return manager.TN("explorer.object", len(node.Children), i18n.Args{"key": node.Key})
 	case "array":
-		return fmt.Sprintf("%s: [array] (%d items)", node.Key, len(node.Children))
+		return manager.TN("explorer.array", len(node.Children), i18n.Args{"key": node.Key})
 	case "string":
-		return fmt.Sprintf("%s: %s", node.Key, node.Value)
-	case "number":
-		return fmt.Sprintf("%s: %v", node.Key, node.Value)
-	case "boolean":
-		return fmt.Sprintf("%s: %v", node.Key, node.Value)
+		return fmt.Sprintf("%s: \"%s\"", node.Key, node.Value)
 	case "null":
 		return fmt.Sprintf("%s: null", node.Key)
 	default:
@@ -251,58 +452,51 @@ func (je *JSONExplorer) formatNode(node *JSONNode) string {
 }
 
 // colorizeNode aplica cores ao nó baseado no tipo
-func (je *JSONExplorer) colorizeNode(node *JSONNode, line string) {
+func (je *JSONExplorer) colorizeNode(node *JSONNode, line string) string {
 	switch node.Type {
 	case "object":
-		objColor := color.New(color.FgBlue, color.Bold)
-		fmt.Println(objColor.Sprint(line))
+		return color.New(color.FgBlue, color.Bold).Sprint(line)
 	case "array":
-		arrColor := color.New(color.FgMagenta, color.Bold)
-		fmt.Println(arrColor.Sprint(line))
+		return color.New(color.FgMagenta, color.Bold).Sprint(line)
 	case "string":
-		strColor := color.New(color.FgGreen)
-		fmt.Println(strColor.Sprint(line))
+		return color.New(color.FgGreen).Sprint(line)
 	case "number":
-		numColor := color.New(color.FgCyan)
-		fmt.Println(numColor.Sprint(line))
+		return color.New(color.FgCyan).Sprint(line)
 	case "boolean":
-		boolColor := color.New(color.FgYellow, color.Bold)
-		fmt.Println(boolColor.Sprint(line))
+		return color.New(color.FgYellow, color.Bold).Sprint(line)
 	case "null":
-		nullColor := color.New(color.FgRed, color.Bold)
-		fmt.Println(nullColor.Sprint(line))
+		return color.New(color.FgRed, color.Bold).Sprint(line)
 	default:
-		fmt.Println(line)
+		return line
 	}
 }
 
-// showInstructions mostra as instruções de navegação
-func (je *JSONExplorer) showInstructions() {
+// showInstructions mostra as instruções de navegação e o estado da busca
+func (je *JSONExplorer) showInstructions(width int) {
+	manager := i18n.GetInstance()
+
 	fmt.Println()
 	infoColor := color.New(color.FgCyan)
-	infoColor.Println("Navegação: ↑↓ (mover) | →← (expandir/colapsar) | Enter (toggle) | Q (sair)")
-}
-
-// moveUp move o cursor para cima
-func (je *JSONExplorer) moveUp() {
-	visibleNodes := je.getVisibleNodes(1000) // Número grande para pegar todos
-	for i, node := range visibleNodes {
-		if node == je.cursor && i > 0 {
-			je.cursor = visibleNodes[i-1]
-			break
-		}
+	infoColor.Println(truncate(manager.T("explorer.instructions_navigation"), width))
+	infoColor.Println(truncate(manager.T("explorer.instructions_actions"), width))
+
+	searchColor := color.New(color.FgYellow, color.Bold)
+	switch {
+	case je.searching:
+		searchColor.Printf("/%s", je.query)
+	case je.query != "" && len(je.matches) == 0:
+		searchColor.Print(manager.T("explorer.search_no_results", je.query))
+	case je.query != "":
+		searchColor.Print(manager.T("explorer.search_results", je.query, je.match+1, len(je.matches)))
 	}
 }
 
-// moveDown move o cursor para baixo
-func (je *JSONExplorer) moveDown() {
-	visibleNodes := je.getVisibleNodes(1000)
-	for i, node := range visibleNodes {
-		if node == je.cursor && i < len(visibleNodes)-1 {
-			je.cursor = visibleNodes[i+1]
-			break
-		}
-	}
+// moveBy move o cursor delta posições entre os nós visíveis
+func (je *JSONExplorer) moveBy(delta int) {
+	visibleNodes := je.getVisibleNodes()
+	index := indexOf(visibleNodes, je.cursor) + delta
+	index = max(min(index, len(visibleNodes)-1), 0)
+	je.cursor = visibleNodes[index]
 }
 
 // moveLeft move o cursor para a esquerda (colapsar)
@@ -330,6 +524,16 @@ func (je *JSONExplorer) toggleExpand() {
 	}
 }
 
+// setExpanded expande ou colapsa o nó e todos os seus descendentes
+func (je *JSONExplorer) setExpanded(node *JSONNode, expanded bool) {
+	if node.Type == "object" || node.Type == "array" {
+		node.IsExpanded = expanded
+	}
+	for _, child := range node.Children {
+		je.setExpanded(child, expanded)
+	}
+}
+
 // goToRoot vai para o nó raiz
 func (je *JSONExplorer) goToRoot() {
 	je.cursor = je.root
@@ -337,131 +541,101 @@ func (je *JSONExplorer) goToRoot() {
 
 // goToEnd vai para o último nó visível
 func (je *JSONExplorer) goToEnd() {
-	visibleNodes := je.getVisibleNodes(1000)
+	visibleNodes := je.getVisibleNodes()
 	if len(visibleNodes) > 0 {
 		je.cursor = visibleNodes[len(visibleNodes)-1]
 	}
 }
 
-// readKey lê uma tecla do teclado
-func (je *JSONExplorer) readKey() string {
-	reader := bufio.NewReader(os.Stdin)
-	char, _, err := reader.ReadRune()
-	if err != nil {
-		return "q"
-	}
-
-	// Verificar sequências especiais
-	if char == 27 { // ESC
-		next, _, _ := reader.ReadRune()
-		if next == 91 { // [
-			third, _, _ := reader.ReadRune()
-			switch third {
-			case 65:
-				return "up"
-			case 66:
-				return "down"
-			case 67:
-				return "right"
-			case 68:
-				return "left"
-			case 72:
-				return "home"
-			case 70:
-				return "end"
+// runSearch procura a consulta atual em chaves e valores de toda a árvore
+func (je *JSONExplorer) runSearch() {
+	je.matches = nil
+	je.match = 0
+	if je.query == "" {
+		return
+	}
+
+	query := strings.ToLower(je.query)
+	var walk func(node *JSONNode)
+	walk = func(node *JSONNode) {
+		if node != je.root {
+			text := strings.ToLower(node.Key)
+			if node.Value != nil {
+				text += " " + strings.ToLower(fmt.Sprint(node.Value))
 			}
+			if strings.Contains(text, query) {
+				je.matches = append(je.matches, node)
+			}
+		}
+		for _, child := range node.Children {
+			walk(child)
 		}
 	}
+	walk(je.root)
 
-	// Verificar teclas especiais
-	switch char {
-	case 13:
-		return "enter"
-	case 32:
-		return " "
-	case 113, 81: // q, Q
-		return "q"
-	case 106, 74: // j, J
-		return "down"
-	case 107, 75: // k, K
-		return "up"
-	case 104, 72: // h, H
-		return "left"
-	case 108, 76: // l, L
-		return "right"
-	}
-
-	return string(char)
-}
-
-// clearScreen limpa a tela
-func (je *JSONExplorer) clearScreen() {
-	var cmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		cmd = exec.Command("cmd", "/c", "cls")
-	} else {
-		cmd = exec.Command("clear")
+	if len(je.matches) > 0 {
+		je.revealNode(je.matches[0])
 	}
-	cmd.Stdout = os.Stdout
-	cmd.Run()
 }
 
-// getTerminalSize obtém o tamanho do terminal
-func (je *JSONExplorer) getTerminalSize() (width, height int) {
-	// Implementação básica - retorna valores padrão
-	return 80, 24
+// nextMatch avança (ou volta) para o próximo resultado da busca
+func (je *JSONExplorer) nextMatch(direction int) {
+	if len(je.matches) == 0 {
+		return
+	}
+	je.match = (je.match + direction + len(je.matches)) % len(je.matches)
+	je.revealNode(je.matches[je.match])
 }
 
-// setRawMode configura o terminal em modo raw
-func (t *Terminal) setRawMode() error {
-	if runtime.GOOS == "windows" {
-		return nil // Windows não suporta termios
+// revealNode expande os ancestrais do nó e move o cursor até ele
+func (je *JSONExplorer) revealNode(node *JSONNode) {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		parent.IsExpanded = true
 	}
+	je.cursor = node
+}
 
-	fd := int(os.Stdin.Fd())
-	termios, err := t.getTermios(fd)
+// isMatch indica se o nó é um resultado da busca atual
+func (je *JSONExplorer) isMatch(node *JSONNode) bool {
+	for _, match := range je.matches {
+		if match == node {
+			return true
+		}
+	}
+	return false
+}
+
+// printSubtree imprime a subárvore selecionada como JSON formatado
+func (je *JSONExplorer) printSubtree(node *JSONNode) error {
+	compact, err := node.MarshalJSON()
 	if err != nil {
 		return err
 	}
 
-	t.originalState = &TerminalState{termios: *termios}
-
-	// Configurar modo raw
-	termios.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG
-	termios.Iflag &^= syscall.IXON | syscall.IXOFF | syscall.ICRNL
-	termios.Cflag &^= syscall.CSIZE | syscall.PARENB
-	termios.Cflag |= syscall.CS8
-	termios.Cc[syscall.VMIN] = 1
-	termios.Cc[syscall.VTIME] = 0
-
-	return t.setTermios(fd, termios)
-}
-
-// restoreMode restaura o modo original do terminal
-func (t *Terminal) restoreMode() error {
-	if t.originalState == nil || runtime.GOOS == "windows" {
-		return nil
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact, "", "  "); err != nil {
+		return err
 	}
 
-	fd := int(os.Stdin.Fd())
-	return t.setTermios(fd, &t.originalState.termios)
+	fmt.Println(je.output.colorizeJSON(indented.String()))
+	return nil
 }
 
-// getTermios obtém as configurações do terminal
-func (t *Terminal) getTermios(fd int) (*syscall.Termios, error) {
-	var termios syscall.Termios
-	_, _, err := syscall.Syscall6(syscall.SYS_IOCTL, uintptr(fd), syscall.TCGETS, uintptr(unsafe.Pointer(&termios)), 0, 0, 0)
-	if err != 0 {
-		return nil, err
+// indexOf retorna a posição do nó na lista, ou -1
+func indexOf(nodes []*JSONNode, target *JSONNode) int {
+	for i, node := range nodes {
+		if node == target {
+			return i
+		}
 	}
-	return &termios, nil
+	return -1
 }
 
-// setTermios define as configurações do terminal
-func (t *Terminal) setTermios(fd int, termios *syscall.Termios) error {
-	_, _, err := syscall.Syscall6(syscall.SYS_IOCTL, uintptr(fd), syscall.TCSETS, uintptr(unsafe.Pointer(termios)), 0, 0, 0)
-	if err != 0 {
-		return err
+// truncate corta a linha para caber na largura do terminal
+func truncate(line string, width int) string {
+	if width <= 1 || utf8.RuneCountInString(line) < width {
+		return line
 	}
-	return nil
+	runes := []rune(line)
+	return string(runes[:width-2]) + "…"
 }
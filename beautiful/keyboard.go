@@ -0,0 +1,201 @@
+package beautiful
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Terminal gerencia operações do terminal
+type Terminal struct {
+	originalState *TerminalState
+	fd            int
+}
+
+// TerminalState armazena o estado original do terminal
+type TerminalState struct {
+	termios syscall.Termios
+}
+
+// winsize espelha a estrutura usada pelo ioctl TIOCGWINSZ
+type winsize struct {
+	Row    uint16
+	Col    uint16
+	Xpixel uint16
+	Ypixel uint16
+}
+
+var (
+	keyboards      = make(map[*os.File]chan string)
+	keyboardsMutex sync.Mutex
+)
+
+// keyboard retorna o canal de teclas do arquivo de entrada. A leitura é
+// feita por uma única goroutine por arquivo, compartilhada entre as telas,
+// para que nenhuma tecla seja perdida ao trocar de uma tela para outra.
+func keyboard(input *os.File) <-chan string {
+	keyboardsMutex.Lock()
+	defer keyboardsMutex.Unlock()
+
+	if keys, ok := keyboards[input]; ok {
+		return keys
+	}
+
+	keys := make(chan string)
+	keyboards[input] = keys
+	go readKeys(input, keys)
+	return keys
+}
+
+// readKeys lê o teclado continuamente e envia as teclas pelo canal
+func readKeys(input *os.File, keys chan<- string) {
+	defer close(keys)
+
+	reader := bufio.NewReader(input)
+	for {
+		key, err := readKey(reader)
+		if err != nil {
+			return
+		}
+		keys <- key
+	}
+}
+
+// readKey lê uma tecla do teclado
+func readKey(reader *bufio.Reader) (string, error) {
+	char, _, err := reader.ReadRune()
+	if err != nil {
+		return "", err
+	}
+
+	// Verificar sequências especiais
+	if char == 27 { // ESC
+		if reader.Buffered() == 0 {
+			return "esc", nil
+		}
+		next, _, _ := reader.ReadRune()
+		if next != '[' && next != 'O' {
+			return "esc", nil
+		}
+		third, _, _ := reader.ReadRune()
+		switch third {
+		case 'A':
+			return "up", nil
+		case 'B':
+			return "down", nil
+		case 'C':
+			return "right", nil
+		case 'D':
+			return "left", nil
+		case 'H':
+			return "home", nil
+		case 'F':
+			return "end", nil
+		case '1', '4', '5', '6':
+			// Sequências no formato ESC [ n ~
+			reader.ReadRune()
+			switch third {
+			case '1':
+				return "home", nil
+			case '4':
+				return "end", nil
+			case '5':
+				return "pgup", nil
+			case '6':
+				return "pgdown", nil
+			}
+		}
+		return "esc", nil
+	}
+
+	// Verificar teclas especiais
+	switch char {
+	case 3:
+		return "ctrl+c", nil
+	case 13, 10:
+		return "enter", nil
+	case 127, 8:
+		return "backspace", nil
+	}
+
+	return string(char), nil
+}
+
+// clearScreen limpa a tela
+func clearScreen() {
+	if runtime.GOOS == "windows" {
+		cmd := exec.Command("cmd", "/c", "cls")
+		cmd.Stdout = os.Stdout
+		cmd.Run()
+		return
+	}
+	fmt.Print("\033[H\033[2J")
+}
+
+// terminalSize obtém o tamanho do terminal
+func terminalSize() (width, height int) {
+	ws := &winsize{}
+	_, _, err := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(ws)))
+	if err != 0 || ws.Row == 0 || ws.Col == 0 {
+		return 80, 24
+	}
+	return int(ws.Col), int(ws.Row)
+}
+
+// setRawMode configura o terminal em modo raw
+func (t *Terminal) setRawMode() error {
+	if runtime.GOOS == "windows" {
+		return nil // Windows não suporta termios
+	}
+
+	fd := t.fd
+	termios, err := t.getTermios(fd)
+	if err != nil {
+		return err
+	}
+
+	t.originalState = &TerminalState{termios: *termios}
+
+	// Configurar modo raw
+	termios.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG
+	termios.Iflag &^= syscall.IXON | syscall.IXOFF | syscall.ICRNL
+	termios.Cflag &^= syscall.CSIZE | syscall.PARENB
+	termios.Cflag |= syscall.CS8
+	termios.Cc[syscall.VMIN] = 1
+	termios.Cc[syscall.VTIME] = 0
+
+	return t.setTermios(fd, termios)
+}
+
+// restoreMode restaura o modo original do terminal
+func (t *Terminal) restoreMode() error {
+	if t.originalState == nil || runtime.GOOS == "windows" {
+		return nil
+	}
+
+	return t.setTermios(t.fd, &t.originalState.termios)
+}
+
+// getTermios obtém as configurações do terminal
+func (t *Terminal) getTermios(fd int) (*syscall.Termios, error) {
+	var termios syscall.Termios
+	_, _, err := syscall.Syscall6(syscall.SYS_IOCTL, uintptr(fd), syscall.TCGETS, uintptr(unsafe.Pointer(&termios)), 0, 0, 0)
+	if err != 0 {
+		return nil, err
+	}
+	return &termios, nil
+}
+
+// setTermios define as configurações do terminal
+func (t *Terminal) setTermios(fd int, termios *syscall.Termios) error {
+	_, _, err := syscall.Syscall6(syscall.SYS_IOCTL, uintptr(fd), syscall.TCSETS, uintptr(unsafe.Pointer(termios)), 0, 0, 0)
+	if err != 0 {
+		return err
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 )
@@ -19,7 +20,7 @@ type Output struct {
 func NewOutput(rawMode bool) *Output {
 
 	return &Output{
-		rawMode: rawMode,
+		rawMode: rawMode || settings.plain,
 	}
 }
 
@@ -39,8 +40,7 @@ func (bo *Output) PrintData(data interface{}) {
 		return
 	}
 
-	expFlag := os.Getenv("EXPLORE_JSON") == "1"
-	if expFlag {
+	if exploreEnabled() {
 		explorer := NewJSONExplorer(bo)
 		if err := explorer.ExploreJSON(jsonData); err != nil {
 			fmt.Println(err)
@@ -85,10 +85,10 @@ func (bo *Output) PrintSuccess(message string) {
 	}
 
 	successColor := color.New(color.FgGreen, color.Bold)
-	successColor.Printf("✅ %s\n", message)
+	successColor.Printf("%s%s\n", prefix("✅", "Success"), message)
 }
 
-// PrintError embelezar mensagens de erro
+// PrintError embelezar mensagens de erro (sempre em stderr)
 func (bo *Output) PrintError(message string, emoji bool) {
 	if bo.rawMode {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", message)
@@ -97,10 +97,31 @@ func (bo *Output) PrintError(message string, emoji bool) {
 
 	errorColor := color.New(color.FgRed, color.Bold)
 	if emoji {
-		errorColor.Printf("❌ %s\n", message)
+		errorColor.Fprintf(os.Stderr, "%s%s\n", prefix("❌", "Error"), message)
 		return
 	}
-	errorColor.Printf("Error: %s\n", message)
+	errorColor.Fprintf(os.Stderr, "Error: %s\n", message)
+}
+
+// PrintErrorJSON escreve um erro estruturado como JSON em stderr
+func (bo *Output) PrintErrorJSON(data interface{}) error {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, string(jsonData))
+	return nil
+}
+
+// PrintHint embelezar dicas de correção de erros (sempre em stderr)
+func (bo *Output) PrintHint(title, message string) {
+	if bo.rawMode {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", title, message)
+		return
+	}
+
+	hintColor := color.New(color.FgYellow)
+	hintColor.Fprintf(os.Stderr, "%s%s: %s\n", prefix("💡", ""), title, message)
 }
 
 // PrintWarning embelezar mensagens de aviso
@@ -111,7 +132,7 @@ func (bo *Output) PrintWarning(message string) {
 	}
 
 	warningColor := color.New(color.FgYellow, color.Bold)
-	warningColor.Printf("⚠️  %s\n", message)
+	warningColor.Printf("%s%s\n", prefix("⚠️ ", "Warning"), message)
 }
 
 // PrintInfo embelezar mensagens informativas
@@ -122,7 +143,7 @@ func (bo *Output) PrintInfo(message string) {
 	}
 
 	infoColor := color.New(color.FgCyan, color.Bold)
-	infoColor.Printf("ℹ️  %s\n", message)
+	infoColor.Printf("%s%s\n", prefix("ℹ️ ", "Info"), message)
 }
 
 // PrintTable embelezar dados em formato de tabela
@@ -141,14 +162,15 @@ func (bo *Output) PrintTable(headers []string, rows [][]string) {
 	rowColor := color.New(color.FgWhite)
 
 	// Calcular larguras das colunas
+	// O fmt preenche por runas, então as larguras também são contadas em runas
 	widths := make([]int, len(headers))
 	for i, header := range headers {
-		widths[i] = len(header)
+		widths[i] = utf8.RuneCountInString(header)
 	}
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
+			if i < len(widths) && utf8.RuneCountInString(cell) > widths[i] {
+				widths[i] = utf8.RuneCountInString(cell)
 			}
 		}
 	}
@@ -219,7 +241,7 @@ func (bo *Output) PrintList(title string, items []string) {
 	}
 
 	titleColor := color.New(color.FgBlue, color.Bold)
-	titleColor.Printf("📋 %s:\n", title)
+	titleColor.Printf("%s%s:\n", prefix("📋", ""), title)
 
 	itemColor := color.New(color.FgCyan)
 	for i, item := range items {
@@ -373,7 +395,7 @@ func (bo *Output) PrintProgress(current, total int, message string) {
 	}
 
 	progressColor := color.New(color.FgBlue, color.Bold)
-	progressColor.Printf("🔄 %s: %d/%d\n", message, current, total)
+	progressColor.Printf("%s%s: %d/%d\n", prefix("🔄", ""), message, current, total)
 }
 
 // PrintHeader embelezar cabeçalhos de seção
@@ -384,6 +406,6 @@ func (bo *Output) PrintHeader(title string) {
 	}
 
 	headerColor := color.New(color.FgCyan, color.Bold)
-	headerColor.Printf("\n🎯 %s\n", title)
+	headerColor.Printf("\n%s%s\n", prefix("🎯", ""), title)
 	headerColor.Println(strings.Repeat("─", len(title)+4))
 }
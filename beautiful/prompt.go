@@ -0,0 +1,108 @@
+package beautiful
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"gfcli/i18n"
+
+	"github.com/fatih/color"
+)
+
+// ErrCancelled indica que o usuário desistiu de uma pergunta com Esc ou Ctrl+C
+var ErrCancelled = errors.New("cancelled")
+
+// Ask lê uma linha de texto do terminal. O valor inicial já aparece
+// preenchido e pode ser editado com backspace.
+func Ask(label, initial string) (string, error) {
+	return readLine(label, initial, false)
+}
+
+// AskSecret lê uma linha de texto sem exibir o que é digitado
+func AskSecret(label string) (string, error) {
+	return readLine(label, "", true)
+}
+
+// AskConfirm faz uma pergunta de sim/não. Qualquer resposta diferente de
+// sim é tratada como não.
+func AskConfirm(question string) (bool, error) {
+	answer, err := readLine(fmt.Sprintf("%s %s", question, i18n.GetInstance().T("cli.confirm_options")), "", false)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "s", "yes", "sim", "si", "sí":
+		return true, nil
+	}
+	return false, nil
+}
+
+// Choose exibe as opções em um seletor de tela cheia e retorna o índice
+// escolhido. Voltar ou sair do seletor cancela a escolha.
+func Choose(output *Output, title string, headers []string, rows [][]string, initial int) (int, error) {
+	selector := NewSelector(output, title, headers, rows)
+	selector.Select(initial)
+	defer clearScreen()
+
+	index, key, err := selector.Run()
+	if err != nil {
+		return 0, err
+	}
+	if key != SelectorEnter {
+		return 0, ErrCancelled
+	}
+	return index, nil
+}
+
+// readLine edita uma linha em modo raw, usando o mesmo leitor de teclado
+// compartilhado pelo explorador e pelo seletor
+func readLine(label, initial string, secret bool) (string, error) {
+	terminal := &Terminal{fd: int(os.Stdin.Fd())}
+	if err := terminal.setRawMode(); err != nil {
+		return "", fmt.Errorf(i18n.GetInstance().T("explorer.terminal_error"), err)
+	}
+	defer terminal.restoreMode()
+
+	labelColor := color.New(color.FgCyan, color.Bold)
+	labelColor.Printf("%s: ", label)
+
+	value := initial
+	if !secret {
+		fmt.Print(value)
+	}
+
+	keys := keyboard(os.Stdin)
+	for {
+		key, ok := <-keys
+		if !ok {
+			fmt.Println()
+			return "", ErrCancelled
+		}
+		switch key {
+		case "enter":
+			fmt.Println()
+			return value, nil
+		case "esc", "ctrl+c":
+			fmt.Println()
+			return "", ErrCancelled
+		case "backspace":
+			if len(value) > 0 {
+				_, size := utf8.DecodeLastRuneInString(value)
+				value = value[:len(value)-size]
+				if !secret {
+					fmt.Print("\b \b")
+				}
+			}
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				value += key
+				if !secret {
+					fmt.Print(key)
+				}
+			}
+		}
+	}
+}
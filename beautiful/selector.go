@@ -0,0 +1,291 @@
+package beautiful
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"unicode/utf8"
+
+	"gfcli/i18n"
+
+	"github.com/fatih/color"
+)
+
+// Teclas retornadas pelo Selector.Run além dos atalhos configurados
+const (
+	SelectorEnter = "enter"
+	SelectorBack  = "back"
+	SelectorQuit  = "quit"
+)
+
+// Linhas reservadas para cabeçalho, títulos das colunas e rodapé
+const selectorReservedLines = 9
+
+// Hotkey é um atalho de teclado exibido no rodapé do seletor
+type Hotkey struct {
+	Key   string
+	Label string
+}
+
+// Selector exibe uma lista navegável em tela cheia, usando o mesmo
+// controle de terminal do explorador JSON
+type Selector struct {
+	output   *Output
+	terminal *Terminal
+	input    *os.File
+
+	Title   string
+	Headers []string
+	Rows    [][]string
+	Hotkeys []Hotkey
+	Message string
+
+	cursor int
+	offset int
+	prompt string
+}
+
+// NewSelector cria um seletor para as linhas informadas
+func NewSelector(output *Output, title string, headers []string, rows [][]string) *Selector {
+	return &Selector{
+		output:   output,
+		terminal: &Terminal{fd: int(os.Stdin.Fd())},
+		input:    os.Stdin,
+		Title:    title,
+		Headers:  headers,
+		Rows:     rows,
+	}
+}
+
+// SetInput define de onde o teclado é lido
+func (s *Selector) SetInput(input *os.File) {
+	s.input = input
+	s.terminal.fd = int(input.Fd())
+}
+
+// Select posiciona o cursor na linha informada
+func (s *Selector) Select(index int) {
+	s.cursor = max(min(index, len(s.Rows)-1), 0)
+}
+
+// Run exibe a lista até o usuário abrir uma linha, acionar um atalho, voltar
+// ou sair. Retorna o índice da linha sob o cursor e a tecla que encerrou a interação.
+func (s *Selector) Run() (int, string, error) {
+	if err := s.terminal.setRawMode(); err != nil {
+		return s.cursor, SelectorQuit, fmt.Errorf(i18n.GetInstance().T("explorer.terminal_error"), err)
+	}
+	defer s.terminal.restoreMode()
+
+	keys := keyboard(s.input)
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
+	defer signal.Stop(resize)
+
+	for {
+		s.render()
+
+		select {
+		case <-resize:
+			continue
+		case key, ok := <-keys:
+			if !ok {
+				return s.cursor, SelectorQuit, nil
+			}
+			if result, done := s.handleKey(key); done {
+				return s.cursor, result, nil
+			}
+		}
+	}
+}
+
+// handleKey trata uma tecla e indica se a interação terminou
+func (s *Selector) handleKey(key string) (string, bool) {
+	_, height := terminalSize()
+	page := max(height-selectorReservedLines, 1)
+
+	switch key {
+	case "q", "Q", "ctrl+c":
+		return SelectorQuit, true
+	case "esc", "left", "h", "backspace":
+		return SelectorBack, true
+	case "enter", "right", "l":
+		if len(s.Rows) > 0 {
+			return SelectorEnter, true
+		}
+	case "up", "k":
+		s.Select(s.cursor - 1)
+	case "down", "j":
+		s.Select(s.cursor + 1)
+	case "pgup":
+		s.Select(s.cursor - page)
+	case "pgdown":
+		s.Select(s.cursor + page)
+	case "home", "g":
+		s.Select(0)
+	case "end", "G":
+		s.Select(len(s.Rows) - 1)
+	default:
+		for _, hotkey := range s.Hotkeys {
+			if hotkey.Key == key {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Confirm faz uma pergunta de sim/não no rodapé da tela
+func (s *Selector) Confirm(question string) (bool, error) {
+	if err := s.terminal.setRawMode(); err != nil {
+		return false, err
+	}
+	defer s.terminal.restoreMode()
+
+	s.prompt = fmt.Sprintf("%s %s ", question, i18n.GetInstance().T("cli.confirm_options"))
+	defer func() { s.prompt = "" }()
+	s.render()
+
+	key, ok := <-keyboard(s.input)
+	if !ok {
+		return false, nil
+	}
+	switch strings.ToLower(key) {
+	case "y", "s":
+		return true, nil
+	}
+	return false, nil
+}
+
+// Prompt lê uma linha de texto no rodapé da tela. Retorna false se o
+// usuário cancelar com Esc.
+func (s *Selector) Prompt(label, initial string) (string, bool, error) {
+	if err := s.terminal.setRawMode(); err != nil {
+		return "", false, err
+	}
+	defer s.terminal.restoreMode()
+	defer func() { s.prompt = "" }()
+
+	value := initial
+	keys := keyboard(s.input)
+	for {
+		s.prompt = fmt.Sprintf("%s: %s", label, value)
+		s.render()
+
+		key, ok := <-keys
+		if !ok {
+			return "", false, nil
+		}
+		switch key {
+		case "enter":
+			return value, true, nil
+		case "esc", "ctrl+c":
+			return "", false, nil
+		case "backspace":
+			if len(value) > 0 {
+				_, size := utf8.DecodeLastRuneInString(value)
+				value = value[:len(value)-size]
+			}
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				value += key
+			}
+		}
+	}
+}
+
+// render desenha a lista na tela
+func (s *Selector) render() {
+	manager := i18n.GetInstance()
+	width, height := terminalSize()
+	availableLines := max(height-selectorReservedLines, 1)
+
+	// Ajustar a janela para acompanhar o cursor
+	if s.cursor < s.offset {
+		s.offset = s.cursor
+	}
+	if s.cursor >= s.offset+availableLines {
+		s.offset = s.cursor - availableLines + 1
+	}
+	s.offset = max(min(s.offset, len(s.Rows)-availableLines), 0)
+
+	widths := columnWidths(s.Headers, s.Rows)
+
+	var frame strings.Builder
+	headerColor := color.New(color.FgMagenta, color.Bold)
+	frame.WriteString(headerColor.Sprint(truncate(formatColumns(s.Headers, widths), width)))
+	frame.WriteString("\n")
+
+	end := min(s.offset+availableLines, len(s.Rows))
+	for i := s.offset; i < end; i++ {
+		line := truncate(formatColumns(s.Rows[i], widths), width)
+		if i == s.cursor {
+			line = color.New(color.BgCyan, color.FgBlack, color.Bold).Sprint(line)
+		}
+		frame.WriteString(line)
+		frame.WriteString("\n")
+	}
+	if len(s.Rows) == 0 {
+		frame.WriteString(manager.T("selector.empty"))
+		frame.WriteString("\n")
+		end++
+	}
+	for i := end - s.offset; i < availableLines; i++ {
+		frame.WriteString("\n")
+	}
+
+	clearScreen()
+	s.output.PrintHeader(truncate(s.Title, width-6))
+	fmt.Print(frame.String())
+
+	positionColor := color.New(color.FgYellow)
+	positionColor.Printf("[%d/%d]\n", min(s.cursor+1, len(s.Rows)), len(s.Rows))
+
+	fmt.Println()
+	hints := []string{manager.T("selector.hint_open")}
+	for _, hotkey := range s.Hotkeys {
+		hints = append(hints, fmt.Sprintf("%s (%s)", hotkey.Key, hotkey.Label))
+	}
+	hints = append(hints, manager.T("selector.hint_back"), manager.T("selector.hint_quit"))
+	infoColor := color.New(color.FgCyan)
+	infoColor.Println(truncate(strings.Join(hints, " | "), width))
+
+	messageColor := color.New(color.FgYellow, color.Bold)
+	switch {
+	case s.prompt != "":
+		messageColor.Print(s.prompt)
+	case s.Message != "":
+		messageColor.Print(truncate(s.Message, width))
+	}
+}
+
+// columnWidths calcula a largura de cada coluna
+func columnWidths(headers []string, rows [][]string) []int {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+			}
+		}
+	}
+	return widths
+}
+
+// formatColumns alinha as células de uma linha conforme as larguras
+func formatColumns(cells []string, widths []int) string {
+	var line strings.Builder
+	for i, width := range widths {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		line.WriteString(cell)
+		line.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(cell)+2))
+	}
+	return strings.TrimRight(line.String(), " ")
+}
@@ -0,0 +1,101 @@
+package beautiful
+
+import (
+	"fmt"
+	"os"
+
+	"gfcli/i18n"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+// Modos aceitos pela flag --color
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// terminalSettings guarda as decisões de formatação tomadas para o processo atual
+type terminalSettings struct {
+	plain       bool
+	emoji       bool
+	interactive bool
+	explore     bool
+}
+
+var settings = terminalSettings{
+	emoji:       true,
+	interactive: true,
+}
+
+// ConfigureTerminal decide cores, emojis e interatividade a partir do modo de
+// cor, do modo acessível e de stdout/stdin serem ou não terminais.
+//
+// No modo auto as cores seguem NO_COLOR e CLICOLOR_FORCE; quando stdout não é
+// um terminal a saída passa a ser simples, sem cores nem emojis.
+func ConfigureTerminal(mode string, accessible bool) error {
+	stdoutTTY := IsTerminal(os.Stdout)
+	stdinTTY := IsTerminal(os.Stdin)
+	forced := os.Getenv("CLICOLOR_FORCE") != "" && os.Getenv("CLICOLOR_FORCE") != "0"
+
+	var colorEnabled bool
+	switch mode {
+	case ColorAlways:
+		colorEnabled = true
+	case ColorNever:
+		colorEnabled = false
+	case ColorAuto, "":
+		switch {
+		case os.Getenv("NO_COLOR") != "":
+			colorEnabled = false
+		case forced:
+			colorEnabled = true
+		default:
+			colorEnabled = stdoutTTY && os.Getenv("TERM") != "dumb"
+		}
+	default:
+		return fmt.Errorf(i18n.GetInstance().T("cli.invalid_color_mode"), mode, ColorAuto, ColorAlways, ColorNever)
+	}
+
+	color.NoColor = !colorEnabled
+	settings.plain = (mode == ColorAuto || mode == "") && !stdoutTTY && !forced
+	settings.emoji = !accessible && !settings.plain
+	settings.interactive = stdoutTTY && stdinTTY
+	return nil
+}
+
+// IsTerminal indica se o arquivo está conectado a um terminal
+func IsTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// IsInteractive indica se stdin e stdout são terminais, permitindo recursos
+// interativos como o explorador JSON
+func IsInteractive() bool {
+	return settings.interactive
+}
+
+// EnableExplorer faz o PrintData abrir o explorador JSON interativo
+// quando stdin e stdout forem terminais
+func EnableExplorer(enabled bool) {
+	settings.explore = enabled
+}
+
+// exploreEnabled indica se o explorador deve ser usado pelo PrintData.
+// A variável EXPLORE_JSON=1 é mantida por compatibilidade.
+func exploreEnabled() bool {
+	return (settings.explore || os.Getenv("EXPLORE_JSON") == "1") && IsInteractive()
+}
+
+// prefix retorna o emoji da mensagem, ou o rótulo em texto quando emojis estão desativados
+func prefix(emoji, label string) string {
+	if settings.emoji {
+		return emoji + " "
+	}
+	if label == "" {
+		return ""
+	}
+	return label + ": "
+}
@@ -1,6 +1,10 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
 
 const (
 	apiKeyFlag = "api-key"
@@ -18,7 +22,7 @@ func addApiKeyFlag(cmd *cobra.Command) {
 	cmd.Root().PersistentFlags().String(
 		apiKeyFlag,
 		"",
-		"Use your API key to authenticate with the API",
+		i18n.GetInstance().T("cli.flag.api_key"),
 	)
 }
 
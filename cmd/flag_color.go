@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+
+	"gfcli/beautiful"
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	colorFlag      = "color"
+	accessibleFlag = "accessible"
+)
+
+func addColorFlag(cmd *cobra.Command) {
+	cmd.Root().PersistentFlags().String(
+		colorFlag,
+		beautiful.ColorAuto,
+		i18n.GetInstance().T("cli.flag.color"),
+	)
+}
+
+func addAccessibleFlag(cmd *cobra.Command) {
+	cmd.Root().PersistentFlags().Bool(
+		accessibleFlag,
+		false,
+		i18n.GetInstance().T("cli.flag.accessible"),
+	)
+}
+
+func getColorFlag(cmd *cobra.Command) string {
+	mode, err := cmd.Root().PersistentFlags().GetString(colorFlag)
+	if err != nil {
+		return beautiful.ColorAuto
+	}
+	return mode
+}
+
+func getAccessibleFlag(cmd *cobra.Command) bool {
+	accessible, err := cmd.Root().PersistentFlags().GetBool(accessibleFlag)
+	if err != nil {
+		return false
+	}
+	return accessible || os.Getenv("CLI_ACCESSIBLE") == "1"
+}
+
+// configureTerminal aplica as flags de cor, acessibilidade e explorador à saída
+func configureTerminal(cmd *cobra.Command) error {
+	beautiful.EnableExplorer(getExploreFlag(cmd))
+	return beautiful.ConfigureTerminal(getColorFlag(cmd), getAccessibleFlag(cmd))
+}
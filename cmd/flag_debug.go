@@ -3,6 +3,8 @@ package cmd
 import (
 	"strings"
 
+	"gfcli/i18n"
+
 	"github.com/spf13/cobra"
 )
 
@@ -13,7 +15,7 @@ func addLogDebugFlag(cmd *cobra.Command) {
 	cmd.Root().PersistentFlags().String(
 		logDebugFlag,
 		"error",
-		`Display detailed log information at the debug level`,
+		i18n.GetInstance().T("cli.flag.debug"),
 	)
 }
 
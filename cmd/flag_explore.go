@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+const exploreFlag = "explore"
+
+func addExploreFlag(cmd *cobra.Command) {
+	cmd.Root().PersistentFlags().Bool(
+		exploreFlag,
+		false,
+		i18n.GetInstance().T("cli.flag.explore"),
+	)
+}
+
+func getExploreFlag(cmd *cobra.Command) bool {
+	explore, err := cmd.Root().PersistentFlags().GetBool(exploreFlag)
+	if err != nil {
+		return false
+	}
+	return explore
+}
@@ -1,11 +1,15 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
 
 func addLangFlag(cmd *cobra.Command) {
 	cmd.PersistentFlags().String(
 		"lang",
 		"en-US",
-		"Set the language for the CLI",
+		i18n.GetInstance().T("i18n.language_flag"),
 	)
 }
@@ -1,6 +1,10 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
 
 const noConfirmationFlag = "no-confirm"
 
@@ -8,7 +12,7 @@ func addNoConfirmationFlag(cmd *cobra.Command) {
 	cmd.Root().PersistentFlags().Bool(
 		noConfirmationFlag,
 		false,
-		"Bypasses confirmation step for commands that ask a confirmation from the user",
+		i18n.GetInstance().T("cli.flag.no_confirm"),
 	)
 }
 
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFlag = "output"
+
+	OutputPretty = "pretty"
+	OutputJSON   = "json"
+)
+
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Root().PersistentFlags().String(
+		outputFlag,
+		OutputPretty,
+		i18n.GetInstance().T("cli.flag.output"),
+	)
+}
+
+func getOutputFlag(cmd *cobra.Command) string {
+	output, err := cmd.Root().PersistentFlags().GetString(outputFlag)
+	if err != nil || output == "" {
+		return OutputPretty
+	}
+	return output
+}
+
+// validateOutputFlag recusa formatos diferentes de pretty e json
+func validateOutputFlag(cmd *cobra.Command) error {
+	output, _ := cmd.Root().PersistentFlags().GetString(outputFlag)
+	switch output {
+	case OutputPretty, OutputJSON, "":
+		return nil
+	}
+	return fmt.Errorf(i18n.GetInstance().T("cli.invalid_output_format"), output, OutputPretty, OutputJSON)
+}
@@ -1,12 +1,16 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
 
 func addRawOutputFlag(cmd *cobra.Command) {
 	cmd.PersistentFlags().Bool(
 		"raw",
 		false,
-		"Output raw data, without any formatting or coloring",
+		i18n.GetInstance().T("cli.flag.raw"),
 	)
 }
 
@@ -1,187 +1,375 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strings"
 
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
 	"gfcli/i18n"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
-// i18nCmd representa o comando de internacionalização
-var i18nCmd = &cobra.Command{
-	Use:     "i18n",
-	Short:   "Gerenciar idiomas da interface",
-	GroupID: "other",
-	Long: `Comando para gerenciar idiomas da interface da CLI.
-Permite listar idiomas disponíveis, definir o idioma atual e obter informações sobre traduções.`,
+// i18nCmd cria o comando de internacionalização
+func i18nCmd() *cobra.Command {
+	manager := i18n.GetInstance()
+	cmd := &cobra.Command{
+		Use:     "i18n",
+		Short:   manager.T("i18n.cmd.short"),
+		Long:    manager.T("i18n.cmd.long"),
+		GroupID: "other",
+	}
+
+	cmd.AddCommand(i18nListCmd())
+	cmd.AddCommand(i18nSetCmd())
+	cmd.AddCommand(i18nInfoCmd())
+	cmd.AddCommand(i18nCurrentCmd())
+	cmd.AddCommand(i18nCheckCmd())
+	cmd.AddCommand(i18nExtractCmd())
+	return cmd
 }
 
 // i18nListCmd lista os idiomas disponíveis
-var i18nListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "Listar idiomas disponíveis",
-	Long:  "Lista todos os idiomas disponíveis na CLI com suas informações.",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		manager := i18n.GetInstance()
-		languages := manager.GetAvailableLanguages()
-		currentLang := manager.GetLanguage()
-
-		if len(languages) == 0 {
-			fmt.Println("Nenhum idioma disponível.")
+func i18nListCmd() *cobra.Command {
+	manager := i18n.GetInstance()
+	return &cobra.Command{
+		Use:   "list",
+		Short: manager.T("i18n.list.short"),
+		Long:  manager.T("i18n.list.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			languages := manager.GetAvailableLanguages()
+			sort.Strings(languages)
+			currentLang := manager.GetLanguage()
+
+			if len(languages) == 0 {
+				fmt.Println(manager.T("i18n.list.empty"))
+				return nil
+			}
+
+			beautiful.NewOutput(getRawOutputFlag(cmd)).PrintHeader(manager.T("i18n.list.title"))
+			fmt.Println()
+
+			for _, code := range languages {
+				info, err := manager.GetLanguageInfo(code)
+				if err != nil {
+					continue
+				}
+
+				// Destacar idioma atual
+				if code == currentLang {
+					currentColor := color.New(color.FgGreen, color.Bold)
+					currentColor.Printf("  * %s (%s)\n", info.NativeName, code)
+				} else {
+					fmt.Printf("    %s (%s)\n", info.NativeName, code)
+				}
+			}
+
+			fmt.Println()
+			noteColor := color.New(color.FgYellow)
+			noteColor.Println(manager.T("i18n.list.current", currentLang))
+			noteColor.Println(manager.T("i18n.list.hint"))
+
 			return nil
-		}
+		},
+	}
+}
 
-		headerColor := color.New(color.FgCyan, color.Bold)
-		headerColor.Println("🌍 Idiomas Disponíveis:")
-		fmt.Println()
+// i18nSetCmd define o idioma e o grava na configuração do usuário
+func i18nSetCmd() *cobra.Command {
+	manager := i18n.GetInstance()
+	return &cobra.Command{
+		Use:   "set [code]",
+		Short: manager.T("i18n.set.short"),
+		Long:  manager.T("i18n.set.long"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			code := args[0]
 
-		for _, code := range languages {
-			info, err := manager.GetLanguageInfo(code)
+			// Definir o idioma, aceitando variações regionais atendidas pelo idioma base
+			if err := manager.SetLanguage(code); err != nil {
+				return err
+			}
+			info, err := manager.GetLanguageInfo(manager.GetLanguage())
 			if err != nil {
-				continue
+				return err
 			}
 
-			// Destacar idioma atual
-			if code == currentLang {
-				currentColor := color.New(color.FgGreen, color.Bold)
-				currentColor.Printf("  ✓ %s (%s)\n", info.NativeName, code)
-			} else {
-				fmt.Printf("    %s (%s)\n", info.NativeName, code)
+			config, err := cmdutils.LoadUserConfig()
+			if err != nil {
+				return fmt.Errorf(manager.T("i18n.set.save_failed"), err)
+			}
+			config[cmdutils.ConfigLanguage] = code
+			if err := config.Save(); err != nil {
+				return fmt.Errorf(manager.T("i18n.set.save_failed"), err)
 			}
-		}
 
-		fmt.Println()
-		noteColor := color.New(color.FgYellow)
-		noteColor.Printf("Idioma atual: %s\n", currentLang)
-		noteColor.Println("Use 'mgc i18n set <código>' para alterar o idioma.")
+			beautiful.NewOutput(getRawOutputFlag(cmd)).PrintSuccess(manager.T("i18n.set.success", info.NativeName, info.Code))
 
-		return nil
-	},
-}
+			path, _ := cmdutils.UserConfigPath()
+			fmt.Println()
+			noteColor := color.New(color.FgYellow)
+			noteColor.Println(manager.T("i18n.set.persisted", path))
+			fmt.Println(manager.T("i18n.set.override"))
 
-// i18nSetCmd define o idioma atual
-var i18nSetCmd = &cobra.Command{
-	Use:   "set [código]",
-	Short: "Definir idioma atual",
-	Long:  "Define o idioma atual da interface da CLI.",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		code := args[0]
-		manager := i18n.GetInstance()
-
-		// Verificar se o idioma existe
-		info, err := manager.GetLanguageInfo(code)
-		if err != nil {
-			return fmt.Errorf("idioma não encontrado: %s", code)
-		}
-
-		// Definir o idioma
-		if err := manager.SetLanguage(code); err != nil {
-			return err
-		}
-
-		successColor := color.New(color.FgGreen, color.Bold)
-		successColor.Printf("✅ Idioma alterado para: %s (%s)\n", info.NativeName, code)
-
-		// Mostrar como persistir a configuração
-		fmt.Println()
-		noteColor := color.New(color.FgYellow)
-		noteColor.Println("Para persistir esta configuração, você pode:")
-		fmt.Println("  1. Definir a variável de ambiente CLI_LANG:")
-		fmt.Printf("     export CLI_LANG=%s\n", code)
-		fmt.Println("  2. Usar a flag --lang em cada comando:")
-		fmt.Printf("     cli --lang=%s [comando]\n", code)
-
-		return nil
-	},
+			return nil
+		},
+	}
 }
 
 // i18nInfoCmd mostra informações sobre um idioma específico
-var i18nInfoCmd = &cobra.Command{
-	Use:   "info [código]",
-	Short: "Mostrar informações sobre um idioma",
-	Long:  "Mostra informações detalhadas sobre um idioma específico.",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		code := args[0]
-		manager := i18n.GetInstance()
-
-		info, err := manager.GetLanguageInfo(code)
-		if err != nil {
-			return fmt.Errorf("idioma não encontrado: %s", code)
-		}
-
-		headerColor := color.New(color.FgCyan, color.Bold)
-		headerColor.Printf("📋 Informações do Idioma: %s\n\n", info.NativeName)
-
-		fmt.Printf("Código: %s\n", info.Code)
-		fmt.Printf("Nome: %s\n", info.Name)
-		fmt.Printf("Total de Traduções: %d\n", len(info.Translations))
-
-		// Mostrar algumas traduções de exemplo
-		if len(info.Translations) > 0 {
-			fmt.Println("\nExemplos de traduções:")
-			count := 0
-			for key, translation := range info.Translations {
-				if count >= 5 { // Limitar a 5 exemplos
-					break
+func i18nInfoCmd() *cobra.Command {
+	manager := i18n.GetInstance()
+	return &cobra.Command{
+		Use:   "info [code]",
+		Short: manager.T("i18n.info.short"),
+		Long:  manager.T("i18n.info.long"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := manager.GetLanguageInfo(args[0])
+			if err != nil {
+				return err
+			}
+
+			beautiful.NewOutput(getRawOutputFlag(cmd)).PrintHeader(manager.T("i18n.info.title", info.NativeName))
+			fmt.Println()
+
+			fmt.Println(manager.T("i18n.info.code", info.Code))
+			fmt.Println(manager.T("i18n.info.name", info.Name))
+			sources := slices.Clone(info.Sources)
+			for i, source := range sources {
+				if source == i18n.EmbeddedSource {
+					sources[i] = manager.T("i18n.info.embedded")
 				}
-				fmt.Printf("  %s: %s\n", key, translation)
-				count++
 			}
-		}
+			fmt.Println(manager.T("i18n.info.sources", strings.Join(sources, ", ")))
+			fmt.Println(manager.TN("i18n.info.total", len(info.Translations)))
 
-		return nil
-	},
+			// Mostrar algumas traduções de exemplo
+			if len(info.Translations) > 0 {
+				keys := make([]string, 0, len(info.Translations))
+				for key := range info.Translations {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+
+				fmt.Println()
+				fmt.Println(manager.T("i18n.info.examples"))
+				for _, key := range keys[:min(5, len(keys))] {
+					fmt.Printf("  %s: %s\n", key, info.Translations[key])
+				}
+			}
+
+			return nil
+		},
+	}
 }
 
 // i18nCurrentCmd mostra o idioma atual
-var i18nCurrentCmd = &cobra.Command{
-	Use:   "current",
-	Short: "Mostrar idioma atual",
-	Long:  "Mostra informações sobre o idioma atualmente em uso.",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		manager := i18n.GetInstance()
-		currentLang := manager.GetLanguage()
-
-		info, err := manager.GetLanguageInfo(currentLang)
-		if err != nil {
-			return fmt.Errorf("erro ao obter informações do idioma atual: %s", err)
-		}
-
-		headerColor := color.New(color.FgCyan, color.Bold)
-		headerColor.Println("🎯 Idioma Atual:")
-		fmt.Println()
-
-		fmt.Printf("Código: %s\n", info.Code)
-		fmt.Printf("Nome: %s\n", info.Name)
-
-		// Mostrar como o idioma foi detectado
-		fmt.Println()
-		noteColor := color.New(color.FgYellow)
-		noteColor.Println("Detecção de idioma:")
-
-		if os.Getenv("CLI_LANG") != "" {
-			fmt.Printf("  Definido por CLI_LANG: %s\n", os.Getenv("CLI_LANG"))
-		} else if os.Getenv("LANG") != "" {
-			fmt.Printf("  Detectado de LANG: %s\n", os.Getenv("LANG"))
-		} else if os.Getenv("LC_ALL") != "" {
-			fmt.Printf("  Detectado de LC_ALL: %s\n", os.Getenv("LC_ALL"))
-		} else {
-			fmt.Println("  Usando idioma padrão")
-		}
-
-		return nil
-	},
+func i18nCurrentCmd() *cobra.Command {
+	manager := i18n.GetInstance()
+	return &cobra.Command{
+		Use:   "current",
+		Short: manager.T("i18n.current.short"),
+		Long:  manager.T("i18n.current.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			currentLang := manager.GetLanguage()
+
+			info, err := manager.GetLanguageInfo(currentLang)
+			if err != nil {
+				return fmt.Errorf(manager.T("i18n.current.error"), err)
+			}
+
+			beautiful.NewOutput(getRawOutputFlag(cmd)).PrintHeader(manager.T("i18n.current.title"))
+			fmt.Println()
+
+			fmt.Println(manager.T("i18n.info.code", info.Code))
+			fmt.Println(manager.T("i18n.info.name", info.Name))
+			if requested := manager.GetRequestedLanguage(); requested != "" && requested != currentLang {
+				fmt.Println(manager.T("i18n.current.fallback", requested, currentLang))
+			}
+
+			// Mostrar como o idioma foi detectado, na mesma ordem de prioridade do main
+			fmt.Println()
+			noteColor := color.New(color.FgYellow)
+			noteColor.Println(manager.T("i18n.current.detection"))
+
+			saved := cmdutils.SavedLanguage()
+			switch {
+			case cmd.Flags().Changed("lang"):
+				lang, _ := cmd.Flags().GetString("lang")
+				fmt.Println("  " + manager.T("i18n.current.source_flag", lang))
+			case os.Getenv("CLI_LANG") != "":
+				fmt.Println("  " + manager.T("i18n.current.source_env", "CLI_LANG", os.Getenv("CLI_LANG")))
+			case saved != "":
+				path, _ := cmdutils.UserConfigPath()
+				fmt.Println("  " + manager.T("i18n.current.source_config", saved, path))
+			case os.Getenv("LC_ALL") != "":
+				fmt.Println("  " + manager.T("i18n.current.source_env", "LC_ALL", os.Getenv("LC_ALL")))
+			case os.Getenv("LANG") != "":
+				fmt.Println("  " + manager.T("i18n.current.source_env", "LANG", os.Getenv("LANG")))
+			default:
+				fmt.Println("  " + manager.T("i18n.current.source_default"))
+			}
+
+			return nil
+		},
+	}
+}
+
+// i18nCheckCmd verifica a cobertura das traduções
+func i18nCheckCmd() *cobra.Command {
+	manager := i18n.GetInstance()
+	var reference, source string
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: manager.T("i18n.check.short"),
+		Long:  manager.T("i18n.check.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// A árvore de comandos já foi montada, então as chaves de Short,
+			// Long e flags já foram consultadas pelo T
+			usage := i18n.KeyUsage{Keys: make(map[string]bool)}
+			if source != "" {
+				scanned, err := i18n.ScanSourceKeys(source)
+				if err != nil {
+					return err
+				}
+				usage = scanned
+			}
+			for _, key := range manager.UsedKeys() {
+				usage.Keys[key] = true
+			}
+
+			reports, err := manager.Check(reference, usage)
+			if err != nil {
+				return err
+			}
+
+			out := beautiful.NewOutput(getRawOutputFlag(cmd))
+			problemColor := color.New(color.FgYellow)
+			okColor := color.New(color.FgGreen)
+
+			total := 0
+			for _, report := range reports {
+				total += report.Problems()
+
+				out.PrintHeader(report.Code)
+				if report.Problems() == 0 {
+					okColor.Println("  " + manager.T("i18n.check.ok"))
+					continue
+				}
+
+				printKeys := func(title string, keys []string) {
+					if len(keys) == 0 {
+						return
+					}
+					problemColor.Println("  " + manager.T(title, len(keys)))
+					for _, key := range keys {
+						fmt.Printf("    %s\n", key)
+					}
+				}
+				printKeys("i18n.check.missing", report.Missing)
+				printKeys("i18n.check.empty", report.Empty)
+				printKeys("i18n.check.unused", report.Unused)
+
+				if len(report.Placeholders) > 0 {
+					problemColor.Println("  " + manager.T("i18n.check.placeholders", len(report.Placeholders), reference))
+					for _, mismatch := range report.Placeholders {
+						fmt.Printf("    %s: %s ≠ %s\n", mismatch.Key, formatVerbs(mismatch.Reference), formatVerbs(mismatch.Translation))
+					}
+				}
+			}
+			fmt.Println()
+
+			if source == "" {
+				problemColor.Println(manager.T("i18n.check.unused_skipped"))
+			}
+			if total > 0 {
+				return errors.New(manager.TN("i18n.check.failed", total))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reference, "reference", manager.DefaultLanguage(), manager.T("i18n.check.reference_flag"))
+	cmd.Flags().StringVar(&source, "source", "", manager.T("i18n.check.source_flag"))
+	return cmd
 }
 
-func init() {
-	// Adicionar subcomandos ao comando i18n
-	i18nCmd.AddCommand(i18nListCmd)
-	i18nCmd.AddCommand(i18nSetCmd)
-	i18nCmd.AddCommand(i18nInfoCmd)
-	i18nCmd.AddCommand(i18nCurrentCmd)
+func formatVerbs(verbs []string) string {
+	if len(verbs) == 0 {
+		return "-"
+	}
+	return strings.Join(verbs, " ")
+}
+
+// i18nExtractCmd gera o esqueleto de um arquivo de idioma
+func i18nExtractCmd() *cobra.Command {
+	manager := i18n.GetInstance()
+	var reference, output string
+	var fill bool
+
+	cmd := &cobra.Command{
+		Use:   "extract [code]",
+		Short: manager.T("i18n.extract.short"),
+		Long:  manager.T("i18n.extract.long"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			code := args[0]
+			skeleton, err := manager.Skeleton(code, reference)
+			if err != nil {
+				return err
+			}
+
+			if fill {
+				ref, err := manager.GetLanguageInfo(reference)
+				if err != nil {
+					return err
+				}
+				for key, translation := range skeleton.Translations {
+					if translation == "" {
+						skeleton.Translations[key] = ref.Translations[key]
+					}
+				}
+			}
+
+			data, err := json.MarshalIndent(skeleton, "", "  ")
+			if err != nil {
+				return err
+			}
+			data = append(data, '\n')
+
+			if output == "-" {
+				_, err := os.Stdout.Write(data)
+				return err
+			}
+			if output == "" {
+				output = code + ".json"
+			}
+			if err := os.WriteFile(output, data, 0644); err != nil {
+				return err
+			}
+
+			missing := 0
+			for _, translation := range skeleton.Translations {
+				if translation == "" {
+					missing++
+				}
+			}
+			beautiful.NewOutput(getRawOutputFlag(cmd)).PrintSuccess(manager.T("i18n.extract.success", output, len(skeleton.Translations), missing))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reference, "reference", manager.DefaultLanguage(), manager.T("i18n.check.reference_flag"))
+	cmd.Flags().StringVarP(&output, "output-file", "f", "", manager.T("i18n.extract.output_flag"))
+	cmd.Flags().BoolVar(&fill, "fill", false, manager.T("i18n.extract.fill_flag"))
+	return cmd
 }
@@ -31,6 +31,17 @@ func RootCmd(ctx context.Context, version string, manager *i18n.Manager) *cobra.
 
 	rootCmd.SetContext(ctx)
 	rootCmd.SilenceErrors = true
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		// O erro é mostrado aqui para que o cobra não imprima o uso do comando
+		err := configureTerminal(cmd)
+		if err == nil {
+			err = validateOutputFlag(cmd)
+		}
+		if err != nil {
+			printError(cmd, err)
+		}
+		return err
+	}
 
 	rootCmd.AddGroup(&cobra.Group{
 		ID:    "products",
@@ -51,6 +62,10 @@ func RootCmd(ctx context.Context, version string, manager *i18n.Manager) *cobra.
 	addLogDebugFlag(rootCmd)
 	addNoConfirmationFlag(rootCmd)
 	addRawOutputFlag(rootCmd)
+	addOutputFlag(rootCmd)
+	addColorFlag(rootCmd)
+	addAccessibleFlag(rootCmd)
+	addExploreFlag(rootCmd)
 	addLangFlag(rootCmd)
 
 	// Init SDK
@@ -73,9 +88,10 @@ func RootCmd(ctx context.Context, version string, manager *i18n.Manager) *cobra.
 
 	static.RootStatic(rootCmd, *sdkCoreConfig)
 	gen.RootGen(ctx, rootCmd, *sdkCoreConfig)
+	static.RootStaticExtensions(rootCmd, *sdkCoreConfig)
 
 	// Adicionar comando i18n
-	rootCmd.AddCommand(i18nCmd)
+	rootCmd.AddCommand(i18nCmd())
 
 	// Aplicar embelezamento
 	beautifulPrint(rootCmd)
@@ -92,6 +108,8 @@ func beautifulPrint(cmd *cobra.Command) {
 
 	// Configurar função de formatação personalizada
 	cmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		configureTerminal(cmd)
+
 		// Cabeçalho colorido
 		headerColor := color.New(color.FgCyan, color.Bold)
 		headerColor.Printf("%s\n\n", cmd.Short)
@@ -220,10 +238,9 @@ func beautifulPrint(cmd *cobra.Command) {
 
 	// // Configurar função de erro personalizada
 	cmd.SetUsageFunc(func(cmd *cobra.Command) error {
-		if cmd.Context() != nil {
-			if errorHandled, ok := cmd.Context().Value("error_already_handled").(bool); ok && errorHandled {
-				return nil
-			}
+		// Em JSON a saída padrão recebe apenas o erro, sem o texto de uso
+		if errorHandled(cmd) || getOutputFlag(cmd) == OutputJSON {
+			return nil
 		}
 
 		help := cmd.HelpFunc()
@@ -292,19 +309,10 @@ func beautifulPrint(cmd *cobra.Command) {
 	originalRunE := cmd.RunE
 	if originalRunE != nil {
 		cmd.RunE = func(cmd *cobra.Command, args []string) error {
-			rawMode := getRawOutputFlag(cmd)
-			beautifulOutput := beautiful.NewOutput(rawMode)
-
 			err := originalRunE(cmd, args)
-
 			if err != nil {
-				msg, detail := cmdutils.ParseSDKError(err)
-				beautifulOutput.PrintError(msg, true)
-				beautifulOutput.PrintError(detail, false)
-
-				cmd.SetContext(context.WithValue(cmd.Context(), "error_already_handled", true))
+				printError(cmd, err)
 			}
-
 			return err
 		}
 	}
@@ -315,8 +323,49 @@ func beautifulPrint(cmd *cobra.Command) {
 	}
 }
 
+// Execute executa o comando raiz e mostra os erros que não passaram pelo RunE,
+// como os de flags, de argumentos e do PersistentPreRunE
+func Execute(rootCmd *cobra.Command) error {
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil && !errorHandled(cmd) {
+		printError(cmd, err)
+	}
+	return err
+}
+
+// printError mostra o erro no formato escolhido em --output e o marca como tratado
+func printError(cmd *cobra.Command, err error) {
+	manager := i18n.GetInstance()
+	beautifulOutput := beautiful.NewOutput(getRawOutputFlag(cmd))
+
+	hints := cmdutils.Hints(cmd, err)
+	if getOutputFlag(cmd) == OutputJSON {
+		report := cmdutils.BuildErrorReport(err)
+		report.Hints = hints
+		beautifulOutput.PrintErrorJSON(report)
+	} else {
+		msg, detail := cmdutils.ParseSDKError(err)
+		beautifulOutput.PrintError(msg, true)
+		beautifulOutput.PrintError(detail, false)
+		for _, hint := range hints {
+			beautifulOutput.PrintHint(manager.T("hints.title"), hint)
+		}
+	}
+
+	cmd.SetContext(context.WithValue(cmdutils.CommandContext(cmd), "error_already_handled", true))
+}
+
+// errorHandled indica se o erro do comando já foi mostrado
+func errorHandled(cmd *cobra.Command) bool {
+	if cmd.Context() == nil {
+		return false
+	}
+	handled, _ := cmd.Context().Value("error_already_handled").(bool)
+	return handled
+}
+
 func usageTemplate(manager *i18n.Manager) string {
-	usageTemplate := `{{if .Runnable}}` + manager.T("cli.usage") + `:{{if .HasAvailableFlags}} [FLAGS]{{end}}{{if .HasAvailableSubCommands}} [COMANDO]{{end}}{{if gt .Aliases 0}}
+	usageTemplate := `{{if .Runnable}}` + manager.T("cli.usage") + `:{{if .HasAvailableFlags}} [FLAGS]{{end}}{{if .HasAvailableSubCommands}} [` + manager.T("cli.command_placeholder") + `]{{end}}{{if gt .Aliases 0}}
 
 	` + manager.T("cli.aliases") + `:
 	  {{.NameAndAliases}}{{end}}{{if .HasExample}}
@@ -333,7 +382,7 @@ func usageTemplate(manager *i18n.Manager) string {
 	` + manager.T("cli.global_flags") + `:
 	{{.InheritedFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasHelpSubCommands}}
 	
-	` + manager.T("cli.additional_help_commands") + `:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+	` + manager.T("cli.additional_help") + `:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
 	  {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
 	
 	` + manager.T("cli.help_more_info") + `.{{end}}
@@ -3,16 +3,37 @@ package config
 import (
 	"fmt"
 
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
 	"github.com/spf13/cobra"
 )
 
 func Delete() *cobra.Command {
+	manager := i18n.GetInstance()
 	cmd := &cobra.Command{
-		Use:   "delete",
-		Short: "Deletar configurações",
-		Long:  `Deletar configurações`,
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Deletando configurações")
+		Use:   "delete [key]",
+		Short: manager.T("cli.config.delete.short"),
+		Long:  manager.T("cli.config.delete.long"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := cmdutils.LoadUserConfig()
+			if err != nil {
+				return err
+			}
+
+			if _, ok := config[args[0]]; !ok {
+				return fmt.Errorf(manager.T("cli.config.not_found"), args[0])
+			}
+			delete(config, args[0])
+			if err := config.Save(); err != nil {
+				return err
+			}
+
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			beautiful.NewOutput(raw).PrintSuccess(manager.T("cli.config.delete.success", args[0]))
+			return nil
 		},
 	}
 	return cmd
@@ -3,16 +3,31 @@ package config
 import (
 	"fmt"
 
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
 	"github.com/spf13/cobra"
 )
 
 func Get() *cobra.Command {
+	manager := i18n.GetInstance()
 	cmd := &cobra.Command{
-		Use:   "get",
-		Short: "Obter configurações",
-		Long:  `Obter configurações`,
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Obter configurações")
+		Use:   "get [key]",
+		Short: manager.T("cli.config.get.short"),
+		Long:  manager.T("cli.config.get.long"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := cmdutils.LoadUserConfig()
+			if err != nil {
+				return err
+			}
+
+			value, ok := config[args[0]]
+			if !ok {
+				return fmt.Errorf(manager.T("cli.config.not_found"), args[0])
+			}
+			fmt.Println(value)
+			return nil
 		},
 	}
 	return cmd
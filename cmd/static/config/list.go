@@ -3,16 +3,38 @@ package config
 import (
 	"fmt"
 
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
 	"github.com/spf13/cobra"
 )
 
 func List() *cobra.Command {
+	manager := i18n.GetInstance()
 	cmd := &cobra.Command{
 		Use:   "list",
-		Short: "Listar configurações",
-		Long:  `Listar configurações`,
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Listando configurações")
+		Short: manager.T("cli.config.list.short"),
+		Long:  manager.T("cli.config.list.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := cmdutils.LoadUserConfig()
+			if err != nil {
+				return err
+			}
+
+			if len(config) == 0 {
+				fmt.Println(manager.T("cli.config.list.empty"))
+				return nil
+			}
+
+			rows := make([][]string, 0, len(config))
+			for _, key := range config.Keys() {
+				rows = append(rows, []string{key, config[key]})
+			}
+
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			beautiful.NewOutput(raw).PrintTable([]string{manager.T("cli.config.key"), manager.T("cli.config.value")}, rows)
+			return nil
 		},
 	}
 	return cmd
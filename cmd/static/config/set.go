@@ -1,18 +1,42 @@
 package config
 
 import (
-	"fmt"
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
 
 	"github.com/spf13/cobra"
 )
 
 func Set() *cobra.Command {
+	manager := i18n.GetInstance()
 	cmd := &cobra.Command{
-		Use:   "set",
-		Short: "Definir configurações",
-		Long:  `Definir configurações`,
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println("Definindo configurações")
+		Use:   "set [key] [value]",
+		Short: manager.T("cli.config.set.short"),
+		Long:  manager.T("cli.config.set.long"),
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+
+			// O idioma é validado para não gravar um valor que a CLI ignoraria
+			if key == cmdutils.ConfigLanguage {
+				if err := manager.SetLanguage(value); err != nil {
+					return err
+				}
+			}
+
+			config, err := cmdutils.LoadUserConfig()
+			if err != nil {
+				return err
+			}
+			config[key] = value
+			if err := config.Save(); err != nil {
+				return err
+			}
+
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			beautiful.NewOutput(raw).PrintSuccess(manager.T("cli.config.set.success", key, value))
+			return nil
 		},
 	}
 	return cmd
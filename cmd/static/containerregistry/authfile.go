@@ -0,0 +1,208 @@
+package containerregistry
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+)
+
+const (
+	engineDocker = "docker"
+	enginePodman = "podman"
+)
+
+// authFile é o arquivo de credenciais do Docker ou do Podman. As chaves
+// desconhecidas são mantidas como estão, para não perder outras
+// configurações do usuário, como proxies e plugins.
+type authFile struct {
+	path string
+	data map[string]json.RawMessage
+}
+
+// authEntry é uma entrada de auths; auth guarda "usuário:senha" em base64
+type authEntry struct {
+	Auth string `json:"auth,omitempty"`
+}
+
+// helperCredentials é o formato lido pelo comando 'store' dos credential
+// helpers, como o docker-credential-desktop
+type helperCredentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// detectEngine escolhe o Docker, a não ser que só o Podman esteja instalado
+func detectEngine() string {
+	if _, err := exec.LookPath(engineDocker); err != nil {
+		if _, err := exec.LookPath(enginePodman); err == nil {
+			return enginePodman
+		}
+	}
+	return engineDocker
+}
+
+// authFilePath retorna o arquivo lido pelo 'docker login' ou pelo 'podman login'
+func authFilePath(engine string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch engine {
+	case engineDocker:
+		if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+			return filepath.Join(dir, "config.json"), nil
+		}
+		return filepath.Join(home, ".docker", "config.json"), nil
+	case enginePodman:
+		if path := os.Getenv("REGISTRY_AUTH_FILE"); path != "" {
+			return path, nil
+		}
+		if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" && runtime.GOOS == "linux" {
+			return filepath.Join(dir, "containers", "auth.json"), nil
+		}
+		return filepath.Join(home, ".config", "containers", "auth.json"), nil
+	}
+	return "", fmt.Errorf(i18n.GetInstance().T("cli.cr_login.invalid_engine"), engine)
+}
+
+// loadAuthFile lê o arquivo de credenciais; um arquivo inexistente resulta em um arquivo vazio
+func loadAuthFile(path string) (*authFile, error) {
+	file := &authFile{path: path, data: make(map[string]json.RawMessage)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) || (err == nil && len(bytes.TrimSpace(data)) == 0) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &file.data); err != nil {
+		return nil, fmt.Errorf(i18n.GetInstance().T("cli.cr_login.invalid_file"), path, err)
+	}
+	return file, nil
+}
+
+// auths retorna as entradas por hostname do arquivo
+func (f *authFile) auths() map[string]json.RawMessage {
+	auths := make(map[string]json.RawMessage)
+	if raw, ok := f.data["auths"]; ok {
+		json.Unmarshal(raw, &auths)
+	}
+	return auths
+}
+
+func (f *authFile) setAuths(auths map[string]json.RawMessage) error {
+	raw, err := json.Marshal(auths)
+	if err != nil {
+		return err
+	}
+	f.data["auths"] = raw
+	return nil
+}
+
+// helper retorna o credential helper configurado para o host: o do
+// credHelpers ou, na falta dele, o credsStore
+func (f *authFile) helper(host string) string {
+	helpers := make(map[string]string)
+	if raw, ok := f.data["credHelpers"]; ok {
+		json.Unmarshal(raw, &helpers)
+	}
+	if helper := helpers[host]; helper != "" {
+		return helper
+	}
+	var store string
+	if raw, ok := f.data["credsStore"]; ok {
+		json.Unmarshal(raw, &store)
+	}
+	return store
+}
+
+// hasLogin indica se o arquivo já tem um login para o host
+func (f *authFile) hasLogin(host string) bool {
+	_, ok := f.auths()[host]
+	return ok
+}
+
+// setLogin grava as credenciais do host. Com um credential helper, a senha
+// vai para o helper e o arquivo só registra o host, como faz o 'docker login'.
+func (f *authFile) setLogin(host, username, password string) error {
+	entry := authEntry{}
+	if helper := f.helper(host); helper != "" {
+		data, err := json.Marshal(helperCredentials{ServerURL: host, Username: username, Secret: password})
+		if err != nil {
+			return err
+		}
+		if err := runHelper(helper, "store", data); err != nil {
+			return err
+		}
+	} else {
+		entry.Auth = base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	}
+
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	auths := f.auths()
+	auths[host] = raw
+	return f.setAuths(auths)
+}
+
+// removeLogin apaga as credenciais do host e indica se havia alguma
+func (f *authFile) removeLogin(host string) (bool, error) {
+	auths := f.auths()
+	_, found := auths[host]
+	if helper := f.helper(host); helper != "" && found {
+		if err := runHelper(helper, "erase", []byte(host)); err != nil {
+			return false, err
+		}
+	}
+	if !found {
+		return false, nil
+	}
+	delete(auths, host)
+	return true, f.setAuths(auths)
+}
+
+// save grava o arquivo com permissão 0600, sem deixar credenciais pela
+// metade em caso de erro
+func (f *authFile) save() error {
+	data, err := json.MarshalIndent(f.data, "", "\t")
+	if err != nil {
+		return err
+	}
+	return cmdutils.WriteFileAtomic(f.path, append(data, '\n'), 0600)
+}
+
+// runHelper executa um comando do credential helper docker-credential-<helper>
+func runHelper(helper, action string, input []byte) error {
+	manager := i18n.GetInstance()
+
+	name := "docker-credential-" + helper
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return fmt.Errorf(manager.T("cli.cr_login.helper_not_found"), name)
+	}
+
+	// Os helpers reportam os erros na saída padrão
+	var output bytes.Buffer
+	cmd := exec.Command(path, action)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf(manager.T("cli.cr_login.helper_failed"), name, action, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
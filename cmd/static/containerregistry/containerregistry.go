@@ -0,0 +1,54 @@
+package containerregistry
+
+import (
+	"fmt"
+	"path"
+
+	cmdutils "gfcli/cmd_utils"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	containerregistrySdk "github.com/MagaluCloud/mgc-sdk-go/containerregistry"
+	"github.com/spf13/cobra"
+)
+
+// ContainerRegistryCmd adiciona ao grupo gerado 'container-registry' o login
+// e o logout no Docker e no Podman, o --update-login ao 'credentials
+// reset-password', o 'images prune' e o relatório 'usage'.
+func ContainerRegistryCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	group, _, err := parent.Find([]string{"container-registry"})
+	if err != nil || group == nil || group.Name() != "container-registry" {
+		return
+	}
+
+	client := containerregistrySdk.New(&sdkCoreConfig)
+	host := registryHost(sdkCoreConfig)
+	group.AddCommand(loginCmd(client, host))
+	group.AddCommand(logoutCmd(host))
+	group.AddCommand(usageCmd(client))
+	resetPassword(group, client, host)
+	if images := cmdutils.Subcommand(group, "images"); images != nil {
+		images.AddCommand(pruneCmd(client.Images()))
+	}
+}
+
+// registryHost retorna o hostname do registry da região configurada no SDK,
+// como container-registry.br-se1.magalu.cloud
+func registryHost(sdkCoreConfig sdk.CoreClient) string {
+	region := path.Base(string(sdkCoreConfig.GetConfig().BaseURL))
+	return "container-registry." + region + ".magalu.cloud"
+}
+
+// formatBytes formata um tamanho em bytes com a maior unidade binária que
+// o mantém acima de 1
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, exp := float64(size)/unit, 0
+	for value >= unit && exp < 4 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGTP"[exp])
+}
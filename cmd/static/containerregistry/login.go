@@ -0,0 +1,230 @@
+package containerregistry
+
+import (
+	"fmt"
+	"slices"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	containerregistrySdk "github.com/MagaluCloud/mgc-sdk-go/containerregistry"
+	"github.com/spf13/cobra"
+)
+
+const (
+	engineFlag      = "engine"
+	hostFlag        = "host"
+	registryIDFlag  = "registry-id"
+	updateLoginFlag = "update-login"
+)
+
+// loginCmd cria o 'container-registry login', que grava as credenciais da
+// conta no arquivo lido pelo Docker ou pelo Podman
+func loginCmd(client *containerregistrySdk.ContainerRegistryClient, defaultHost string) *cobra.Command {
+	manager := i18n.GetInstance()
+
+	var registryID string
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: manager.T("cli.cr_login.login.short"),
+		Long:  manager.T("cli.cr_login.login.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			output := beautiful.NewOutput(raw)
+			ctx := cmdutils.CommandContext(cmd)
+			host, _ := cmd.Flags().GetString(hostFlag)
+
+			var registry *containerregistrySdk.RegistryResponse
+			if registryID != "" {
+				var err error
+				if registry, err = client.Registries().Get(ctx, registryID); err != nil {
+					return err
+				}
+			}
+
+			credentials, err := client.Credentials().Get(ctx)
+			if err != nil {
+				return err
+			}
+
+			engine, path, err := engineOf(cmd)
+			if err != nil {
+				return err
+			}
+			if err := storeLogin(path, host, credentials); err != nil {
+				return err
+			}
+
+			output.PrintSuccess(manager.T("cli.cr_login.login.success", host, credentials.Username, engine, path))
+			if registry != nil {
+				output.PrintInfo(manager.T("cli.cr_login.login.push_hint", host, registry.Name))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&registryID, registryIDFlag, "", manager.T("cli.cr_login.flag.registry_id"))
+	engineFlags(cmd, defaultHost)
+
+	return cmd
+}
+
+// logoutCmd cria o 'container-registry logout', que remove as credenciais gravadas pelo login
+func logoutCmd(defaultHost string) *cobra.Command {
+	manager := i18n.GetInstance()
+
+	cmd := &cobra.Command{
+		Use:   "logout",
+		Short: manager.T("cli.cr_login.logout.short"),
+		Long:  manager.T("cli.cr_login.logout.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			output := beautiful.NewOutput(raw)
+			host, _ := cmd.Flags().GetString(hostFlag)
+
+			_, path, err := engineOf(cmd)
+			if err != nil {
+				return err
+			}
+			file, err := loadAuthFile(path)
+			if err != nil {
+				return err
+			}
+			removed, err := file.removeLogin(host)
+			if err != nil {
+				return err
+			}
+			if !removed {
+				output.PrintWarning(manager.T("cli.cr_login.logout.not_logged_in", host, path))
+				return nil
+			}
+			if err := file.save(); err != nil {
+				return err
+			}
+			output.PrintSuccess(manager.T("cli.cr_login.logout.success", host, path))
+			return nil
+		},
+	}
+
+	engineFlags(cmd, defaultHost)
+
+	return cmd
+}
+
+// resetPassword adiciona o --update-login ao 'credentials reset-password'
+// gerado: a nova senha substitui a dos logins já gravados para o registry
+func resetPassword(group *cobra.Command, client *containerregistrySdk.ContainerRegistryClient, defaultHost string) {
+	manager := i18n.GetInstance()
+
+	cmd, _, err := group.Find([]string{"credentials", "reset-password"})
+	if err != nil || cmd == nil || cmd.Name() != "reset-password" {
+		return
+	}
+
+	cmd.Flags().Bool(updateLoginFlag, false, manager.T("cli.cr_login.flag.update_login"))
+	engineFlags(cmd, defaultHost)
+
+	previous := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if update, _ := cmd.Flags().GetBool(updateLoginFlag); !update {
+			return previous(cmd, args)
+		}
+
+		raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+		output := beautiful.NewOutput(raw)
+		host, _ := cmd.Flags().GetString(hostFlag)
+
+		// Os arquivos são conferidos antes da troca, para que um arquivo
+		// inválido não deixe a senha nova sem ser gravada
+		paths, err := loginPaths(cmd, host)
+		if err != nil {
+			return err
+		}
+
+		credentials, err := client.Credentials().ResetPassword(cmdutils.CommandContext(cmd))
+		if err != nil {
+			return err
+		}
+		output.PrintData(credentials)
+
+		for _, path := range paths {
+			if err := storeLogin(path, host, credentials); err != nil {
+				return fmt.Errorf(manager.T("cli.cr_login.reset.update_failed"), path, err)
+			}
+			output.PrintSuccess(manager.T("cli.cr_login.reset.updated", host, path))
+		}
+		return nil
+	}
+}
+
+// engineFlags registra as flags comuns de escolha do arquivo de credenciais
+func engineFlags(cmd *cobra.Command, defaultHost string) {
+	manager := i18n.GetInstance()
+
+	cmd.Flags().String(engineFlag, "", manager.T("cli.cr_login.flag.engine"))
+	cmd.Flags().String(hostFlag, defaultHost, manager.T("cli.cr_login.flag.host"))
+}
+
+// engineOf retorna o motor escolhido no --engine, ou o detectado, e o arquivo de credenciais dele
+func engineOf(cmd *cobra.Command) (string, string, error) {
+	engine, _ := cmd.Flags().GetString(engineFlag)
+	if engine == "" {
+		engine = detectEngine()
+	}
+	path, err := authFilePath(engine)
+	return engine, path, err
+}
+
+// loginPaths retorna os arquivos de credenciais a atualizar depois da troca
+// de senha: o do --engine ou, sem ele, todos os que já têm login para o
+// host. Sem nenhum login gravado, é usado o arquivo do motor detectado.
+func loginPaths(cmd *cobra.Command, host string) ([]string, error) {
+	if cmd.Flags().Changed(engineFlag) {
+		_, path, err := engineOf(cmd)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := loadAuthFile(path); err != nil {
+			return nil, err
+		}
+		return []string{path}, nil
+	}
+
+	var paths []string
+	for _, engine := range []string{engineDocker, enginePodman} {
+		path, err := authFilePath(engine)
+		if err != nil {
+			return nil, err
+		}
+		file, err := loadAuthFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if file.hasLogin(host) && !slices.Contains(paths, path) {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) > 0 {
+		return paths, nil
+	}
+
+	_, path, err := engineOf(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return []string{path}, nil
+}
+
+// storeLogin grava as credenciais do host no arquivo
+func storeLogin(path, host string, credentials *containerregistrySdk.CredentialsResponse) error {
+	file, err := loadAuthFile(path)
+	if err != nil {
+		return err
+	}
+	if err := file.setLogin(host, credentials.Username, credentials.Password); err != nil {
+		return err
+	}
+	return file.save()
+}
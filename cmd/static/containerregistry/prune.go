@@ -0,0 +1,299 @@
+package containerregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	containerregistrySdk "github.com/MagaluCloud/mgc-sdk-go/containerregistry"
+	"github.com/spf13/cobra"
+)
+
+// pruneOptions são os critérios do 'images prune'
+type pruneOptions struct {
+	keepLast   int
+	olderThan  time.Duration
+	tagPattern string
+	untagged   bool
+}
+
+// pushedImage é uma imagem com a data de envio já interpretada
+type pushedImage struct {
+	containerregistrySdk.ImageResponse
+	pushedAt time.Time
+}
+
+// pruneCmd cria o 'images prune', que apaga as imagens de um repositório
+// selecionadas pelos critérios de retenção depois de mostrar o plano
+func pruneCmd(service containerregistrySdk.ImagesService) *cobra.Command {
+	manager := i18n.GetInstance()
+
+	var registryID, repository, olderThan string
+	var opts pruneOptions
+	var concurrency int
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: manager.T("cli.cr_prune.short"),
+		Long:  manager.T("cli.cr_prune.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			output := beautiful.NewOutput(raw)
+
+			if olderThan != "" {
+				age, err := parseAge(olderThan)
+				if err != nil {
+					return err
+				}
+				opts.olderThan = age
+			}
+			if opts.tagPattern != "" {
+				if _, err := path.Match(opts.tagPattern, ""); err != nil {
+					return fmt.Errorf(manager.T("cli.cr_prune.invalid_pattern"), opts.tagPattern, err)
+				}
+			}
+			if opts.keepLast < 0 || concurrency < 1 {
+				return errors.New(manager.T("cli.cr_prune.invalid_number"))
+			}
+			if opts.keepLast == 0 && opts.olderThan == 0 && opts.tagPattern == "" && !opts.untagged {
+				return errors.New(manager.T("cli.cr_prune.criteria_required"))
+			}
+
+			ctx, stop := signal.NotifyContext(cmdutils.CommandContext(cmd), os.Interrupt)
+			defer stop()
+
+			images, err := listImages(ctx, service, registryID, repository)
+			if err != nil {
+				return err
+			}
+			remove, kept := opts.plan(images, time.Now())
+			if len(remove) == 0 {
+				output.PrintInfo(manager.T("cli.cr_prune.nothing", len(images)))
+				return nil
+			}
+
+			printPlan(output, remove)
+			output.PrintInfo(manager.TN("cli.cr_prune.plan", len(remove), i18n.Args{
+				"size": formatBytes(totalSize(remove)),
+				"kept": manager.TN("cli.cr_prune.kept", kept),
+			}))
+			if dryRun {
+				return nil
+			}
+			if err := cmdutils.Confirm(cmd, manager.TN("cli.cr_prune.confirm", len(remove), i18n.Args{"repository": repository}), "cli.container_registry.confirm_required", "cli.cr_prune.cancelled"); err != nil {
+				return err
+			}
+
+			deleted, failed := deleteImages(ctx, service, registryID, repository, remove, concurrency, output)
+			output.PrintSuccess(manager.TN("cli.cr_prune.deleted", len(deleted), i18n.Args{"size": formatBytes(totalSize(deleted))}))
+			if skipped := len(remove) - len(deleted) - failed; skipped > 0 {
+				output.PrintWarning(manager.TN("cli.cr_prune.interrupted", skipped))
+			}
+			if failed > 0 {
+				return errors.New(manager.TN("cli.cr_prune.failed", failed))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&registryID, registryIDFlag, "r", "", manager.T("cli.cr_prune.flag.registry_id"))
+	cmd.Flags().StringVarP(&repository, "repository-name", "e", "", manager.T("cli.cr_prune.flag.repository_name"))
+	cmd.Flags().IntVar(&opts.keepLast, "keep-last", 0, manager.T("cli.cr_prune.flag.keep_last"))
+	cmd.Flags().StringVar(&olderThan, "older-than", "", manager.T("cli.cr_prune.flag.older_than"))
+	cmd.Flags().StringVar(&opts.tagPattern, "tag-pattern", "", manager.T("cli.cr_prune.flag.tag_pattern"))
+	cmd.Flags().BoolVar(&opts.untagged, "untagged", false, manager.T("cli.cr_prune.flag.untagged"))
+	cmd.Flags().IntVar(&concurrency, "concurrency", 5, manager.T("cli.cr_prune.flag.concurrency"))
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, manager.T("cli.cr_prune.flag.dry_run"))
+	cmd.MarkFlagRequired(registryIDFlag)
+	cmd.MarkFlagRequired("repository-name")
+
+	return cmd
+}
+
+// plan separa as imagens a apagar. --tag-pattern e --untagged escolhem as
+// imagens consideradas (sem eles, todas); entre elas as --keep-last mais
+// recentes são mantidas e, com --older-than, só as mais antigas que o
+// limite são apagadas. Retorna as imagens a apagar e quantas ficam.
+func (o pruneOptions) plan(images []pushedImage, now time.Time) ([]pushedImage, int) {
+	sort.SliceStable(images, func(i, j int) bool { return images[i].pushedAt.After(images[j].pushedAt) })
+
+	var remove []pushedImage
+	selected := 0
+	for _, image := range images {
+		if !o.selects(image) {
+			continue
+		}
+		selected++
+		if selected <= o.keepLast {
+			continue
+		}
+		// Sem a data de envio não há como saber a idade, então a imagem é mantida
+		if o.olderThan > 0 && (image.pushedAt.IsZero() || now.Sub(image.pushedAt) < o.olderThan) {
+			continue
+		}
+		remove = append(remove, image)
+	}
+	return remove, len(images) - len(remove)
+}
+
+// selects indica se a imagem entra na seleção. Uma imagem só é escolhida
+// pelo padrão quando todas as tags casam, já que apagar o digest remove
+// também as tags que não casam, como a latest.
+func (o pruneOptions) selects(image pushedImage) bool {
+	tags := image.Tags
+	if len(tags) == 0 {
+		return o.untagged || o.tagPattern == ""
+	}
+	if o.tagPattern == "" {
+		return !o.untagged
+	}
+	for _, tag := range tags {
+		if matched, _ := path.Match(o.tagPattern, tag); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// listImages percorre as páginas da listagem de imagens. Uma imagem que
+// aparece em duas páginas, por ter mudado de posição entre as chamadas,
+// entra uma única vez.
+func listImages(ctx context.Context, service containerregistrySdk.ImagesService, registryID, repository string) ([]pushedImage, error) {
+	results, err := cmdutils.Paged(func(limit, offset *int) ([]containerregistrySdk.ImageResponse, error) {
+		page, err := service.List(ctx, registryID, repository, containerregistrySdk.ListOptions{Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		return page.Results, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(results))
+	var images []pushedImage
+	for _, image := range results {
+		if seen[image.Digest] {
+			continue
+		}
+		seen[image.Digest] = true
+		pushedAt, _ := time.Parse(time.RFC3339, image.PushedAt)
+		images = append(images, pushedImage{ImageResponse: image, pushedAt: pushedAt})
+	}
+	return images, nil
+}
+
+// deleteImages apaga as imagens pelo digest com no máximo concurrency
+// chamadas simultâneas. Depois de um Ctrl-C as chamadas em andamento
+// terminam e as restantes não são feitas.
+func deleteImages(ctx context.Context, service containerregistrySdk.ImagesService, registryID, repository string, images []pushedImage, concurrency int, output *beautiful.Output) ([]pushedImage, int) {
+	manager := i18n.GetInstance()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		deleted []pushedImage
+		failed  int
+	)
+	slots := make(chan struct{}, concurrency)
+
+	for _, image := range images {
+		select {
+		case <-ctx.Done():
+		case slots <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(image pushedImage) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			// O contexto sem cancelamento deixa a chamada em andamento terminar
+			err := service.Delete(context.WithoutCancel(ctx), registryID, repository, image.Digest)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				output.PrintWarning(manager.T("cli.cr_prune.delete_failed", shortDigest(image.Digest), err))
+				return
+			}
+			deleted = append(deleted, image)
+		}(image)
+	}
+	wg.Wait()
+	return deleted, failed
+}
+
+func printPlan(output *beautiful.Output, images []pushedImage) {
+	manager := i18n.GetInstance()
+
+	rows := make([][]string, len(images))
+	for i, image := range images {
+		tags := strings.Join(image.Tags, ", ")
+		if tags == "" {
+			tags = "<none>"
+		}
+		rows[i] = []string{shortDigest(image.Digest), tags, image.PushedAt, formatBytes(int64(image.SizeBytes))}
+	}
+	output.PrintTable([]string{
+		manager.T("cli.cr_prune.column.digest"),
+		manager.T("cli.cr_prune.column.tags"),
+		manager.T("cli.cr_prune.column.pushed_at"),
+		manager.T("cli.cr_prune.column.size"),
+	}, rows)
+}
+
+// parseAge interpreta o --older-than: dias (30d), semanas (2w) ou uma duração do Go (12h)
+func parseAge(value string) (time.Duration, error) {
+	manager := i18n.GetInstance()
+
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(number)
+			if err != nil || count <= 0 {
+				return 0, fmt.Errorf(manager.T("cli.cr_prune.invalid_age"), value)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf(manager.T("cli.cr_prune.invalid_age"), value)
+	}
+	return age, nil
+}
+
+func totalSize(images []pushedImage) int64 {
+	var total int64
+	for _, image := range images {
+		total += int64(image.SizeBytes)
+	}
+	return total
+}
+
+// shortDigest encurta o digest como o 'docker images', mantendo o algoritmo
+func shortDigest(digest string) string {
+	algorithm, hash, found := strings.Cut(digest, ":")
+	if !found || len(hash) <= 12 {
+		return digest
+	}
+	return algorithm + ":" + hash[:12]
+}
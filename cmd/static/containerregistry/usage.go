@@ -0,0 +1,265 @@
+package containerregistry
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	containerregistrySdk "github.com/MagaluCloud/mgc-sdk-go/containerregistry"
+	"github.com/spf13/cobra"
+)
+
+// repositoryUsage é o consumo de um repositório, somado das suas imagens
+type repositoryUsage struct {
+	registry   string
+	repository string
+	images     int
+	tags       int
+	size       int64
+	lastPush   time.Time
+}
+
+// usageCmd cria o 'container-registry usage', que percorre registries,
+// repositórios e imagens e mostra o consumo por repositório
+func usageCmd(client *containerregistrySdk.ContainerRegistryClient) *cobra.Command {
+	manager := i18n.GetInstance()
+
+	var registryID, format string
+	var top, concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: manager.T("cli.cr_usage.short"),
+		Long:  manager.T("cli.cr_usage.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			output := beautiful.NewOutput(raw)
+			ctx := cmdutils.CommandContext(cmd)
+
+			if format != "table" && format != "csv" {
+				return fmt.Errorf(manager.T("cli.cr_usage.invalid_format"), format)
+			}
+			if top < 0 || concurrency < 1 {
+				return errors.New(manager.T("cli.cr_usage.invalid_number"))
+			}
+
+			registries, err := listRegistries(ctx, client.Registries(), registryID)
+			if err != nil {
+				return err
+			}
+			usage, err := collectUsage(ctx, client, registries, concurrency)
+			if err != nil {
+				return err
+			}
+
+			sort.SliceStable(usage, func(i, j int) bool {
+				if usage[i].size != usage[j].size {
+					return usage[i].size > usage[j].size
+				}
+				return usage[i].registry+"/"+usage[i].repository < usage[j].registry+"/"+usage[j].repository
+			})
+			var total int64
+			for _, item := range usage {
+				total += item.size
+			}
+			shown := usage
+			if top > 0 && top < len(usage) {
+				shown = usage[:top]
+			}
+
+			if format == "csv" {
+				return writeUsageCSV(shown)
+			}
+
+			rows := make([][]string, len(shown))
+			var shownSize int64
+			for i, item := range shown {
+				shownSize += item.size
+				rows[i] = []string{
+					item.registry,
+					item.repository,
+					strconv.Itoa(item.images),
+					strconv.Itoa(item.tags),
+					formatBytes(item.size),
+					share(item.size, total),
+					formatPush(item.lastPush),
+				}
+			}
+			output.PrintTable([]string{
+				manager.T("cli.cr_usage.column.registry"),
+				manager.T("cli.cr_usage.column.repository"),
+				manager.T("cli.cr_usage.column.images"),
+				manager.T("cli.cr_usage.column.tags"),
+				manager.T("cli.cr_usage.column.size"),
+				manager.T("cli.cr_usage.column.share"),
+				manager.T("cli.cr_usage.column.last_push"),
+			}, rows)
+
+			var storage int64
+			for _, registry := range registries {
+				storage += int64(registry.Storage)
+			}
+			output.PrintInfo(manager.TN("cli.cr_usage.summary", len(usage), i18n.Args{
+				"registries": manager.TN("cli.cr_usage.registries", len(registries)),
+				"size":       formatBytes(total),
+				"storage":    formatBytes(storage),
+			}))
+			if len(shown) < len(usage) {
+				output.PrintInfo(manager.TN("cli.cr_usage.top", len(shown), i18n.Args{"share": share(shownSize, total)}))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&registryID, registryIDFlag, "r", "", manager.T("cli.cr_usage.flag.registry_id"))
+	cmd.Flags().IntVar(&top, "top", 0, manager.T("cli.cr_usage.flag.top"))
+	cmd.Flags().StringVar(&format, "format", "table", manager.T("cli.cr_usage.flag.format"))
+	cmd.Flags().IntVar(&concurrency, "concurrency", 5, manager.T("cli.cr_usage.flag.concurrency"))
+
+	return cmd
+}
+
+// listRegistries retorna o registry do --registry-id ou todos os da conta
+func listRegistries(ctx context.Context, service containerregistrySdk.RegistriesService, registryID string) ([]containerregistrySdk.RegistryResponse, error) {
+	if registryID != "" {
+		registry, err := service.Get(ctx, registryID)
+		if err != nil {
+			return nil, err
+		}
+		return []containerregistrySdk.RegistryResponse{*registry}, nil
+	}
+
+	return cmdutils.Paged(func(limit, offset *int) ([]containerregistrySdk.RegistryResponse, error) {
+		page, err := service.List(ctx, containerregistrySdk.ListOptions{Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		return page.Registries, nil
+	})
+}
+
+// listRepositories percorre as páginas de repositórios de um registry
+func listRepositories(ctx context.Context, service containerregistrySdk.RepositoriesService, registryID string) ([]containerregistrySdk.RepositoryResponse, error) {
+	return cmdutils.Paged(func(limit, offset *int) ([]containerregistrySdk.RepositoryResponse, error) {
+		page, err := service.List(ctx, registryID, containerregistrySdk.ListOptions{Limit: limit, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		return page.Results, nil
+	})
+}
+
+// collectUsage lista as imagens de todos os repositórios, com no máximo
+// concurrency listagens simultâneas, e soma o consumo de cada um
+func collectUsage(ctx context.Context, client *containerregistrySdk.ContainerRegistryClient, registries []containerregistrySdk.RegistryResponse, concurrency int) ([]repositoryUsage, error) {
+	type job struct {
+		registry   containerregistrySdk.RegistryResponse
+		repository string
+	}
+
+	var jobs []job
+	for _, registry := range registries {
+		repositories, err := listRepositories(ctx, client.Repositories(), registry.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range repositories {
+			jobs = append(jobs, job{registry: registry, repository: repository.Name})
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	usage := make([]repositoryUsage, len(jobs))
+	slots := make(chan struct{}, concurrency)
+	for i, item := range jobs {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int, item job) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			images, err := listImages(ctx, client.Images(), item.registry.ID, item.repository)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+			usage[i] = summarize(item.registry.Name, item.repository, images)
+		}(i, item)
+	}
+	wg.Wait()
+	return usage, firstErr
+}
+
+// summarize soma tamanho, tags e o último envio das imagens de um repositório.
+// Camadas compartilhadas entre imagens entram uma vez em cada imagem.
+func summarize(registry, repository string, images []pushedImage) repositoryUsage {
+	usage := repositoryUsage{registry: registry, repository: repository, images: len(images)}
+	for _, image := range images {
+		usage.tags += len(image.Tags)
+		usage.size += int64(image.SizeBytes)
+		if image.pushedAt.After(usage.lastPush) {
+			usage.lastPush = image.pushedAt
+		}
+	}
+	return usage
+}
+
+// writeUsageCSV grava o relatório em CSV na saída padrão, com tamanhos em
+// bytes e datas em RFC 3339 para facilitar o uso em planilhas e scripts
+func writeUsageCSV(usage []repositoryUsage) error {
+	writer := csv.NewWriter(os.Stdout)
+	writer.Write([]string{"registry", "repository", "images", "tags", "size_bytes", "last_push"})
+	for _, item := range usage {
+		lastPush := ""
+		if !item.lastPush.IsZero() {
+			lastPush = item.lastPush.Format(time.RFC3339)
+		}
+		writer.Write([]string{
+			item.registry,
+			item.repository,
+			strconv.Itoa(item.images),
+			strconv.Itoa(item.tags),
+			strconv.FormatInt(item.size, 10),
+			lastPush,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// share formata a fração de part em total como porcentagem
+func share(part, total int64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+func formatPush(pushedAt time.Time) string {
+	if pushedAt.IsZero() {
+		return "-"
+	}
+	return pushedAt.Local().Format("2006-01-02 15:04")
+}
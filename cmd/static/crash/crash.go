@@ -0,0 +1,22 @@
+package crash
+
+import (
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+func CrashCmd(parent *cobra.Command) {
+	manager := i18n.GetInstance()
+	cmd := &cobra.Command{
+		Use:     "crash",
+		Short:   manager.T("cli.crash.short"),
+		Long:    manager.T("cli.crash.long"),
+		GroupID: "other",
+	}
+
+	cmd.AddCommand(List())
+	cmd.AddCommand(Show())
+
+	parent.AddCommand(cmd)
+}
@@ -0,0 +1,44 @@
+package crash
+
+import (
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+func List() *cobra.Command {
+	manager := i18n.GetInstance()
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: manager.T("cli.crash.list.short"),
+		Long:  manager.T("cli.crash.list.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			output := beautiful.NewOutput(raw)
+
+			reports, err := cmdutils.ListCrashReports()
+			if err != nil {
+				return err
+			}
+			if len(reports) == 0 {
+				output.PrintInfo(manager.T("cli.crash.list.empty"))
+				return nil
+			}
+
+			rows := make([][]string, 0, len(reports))
+			for _, report := range reports {
+				rows = append(rows, []string{
+					report.ID,
+					report.Time.Format("2006-01-02 15:04:05"),
+					report.Version,
+					report.Error,
+				})
+			}
+			output.PrintTable([]string{"ID", manager.T("cli.crash.date"), manager.T("cli.version"), manager.T("cli.error")}, rows)
+			return nil
+		},
+	}
+	return cmd
+}
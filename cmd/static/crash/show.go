@@ -0,0 +1,36 @@
+package crash
+
+import (
+	"fmt"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+func Show() *cobra.Command {
+	manager := i18n.GetInstance()
+	cmd := &cobra.Command{
+		Use:   "show [id]",
+		Short: manager.T("cli.crash.show.short"),
+		Long:  manager.T("cli.crash.show.long"),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			output := beautiful.NewOutput(raw)
+
+			report, err := cmdutils.LoadCrashReport(args[0])
+			if err != nil {
+				return fmt.Errorf(manager.T("cli.crash.not_found"), args[0])
+			}
+
+			output.PrintData(report)
+			output.PrintInfo(manager.T("cli.panic_help"))
+			fmt.Println(report.IssueURL())
+			return nil
+		},
+	}
+	return cmd
+}
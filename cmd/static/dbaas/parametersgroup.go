@@ -0,0 +1,420 @@
+package dbaas
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"gfcli/beautiful"
+	"gfcli/cmd/static/resolve"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	dbaasSdk "github.com/MagaluCloud/mgc-sdk-go/dbaas"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Ações de uma mudança do plano
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+)
+
+const idFlag = "id"
+
+// change é uma linha do plano, com a operação que a aplica. group marca as
+// mudanças no próprio grupo, como o nome, e não em um parâmetro.
+type change struct {
+	action  string
+	name    string
+	current string
+	desired string
+	restart bool
+	group   bool
+	apply   func(ctx context.Context) error
+}
+
+// groupPlanner reúne os serviços e a saída usados pelo 'diff' e pelo 'apply'
+type groupPlanner struct {
+	groups     dbaasSdk.ParameterGroupService
+	parameters dbaasSdk.ParameterService
+	engines    dbaasSdk.EngineService
+	output     *beautiful.Output
+	// resolve troca o nome do grupo recebido como argumento pelo ID
+	resolve func(cmd *cobra.Command, value string) (string, error)
+}
+
+// ParametersGroupCmd adiciona ao 'dbaas parameters-group' o export, o diff e
+// o apply, que mantêm os parâmetros de um grupo em um arquivo YAML.
+func ParametersGroupCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	path := []string{"dbaas", "parameters-group"}
+	group, _, err := parent.Find(path)
+	if err != nil || group == nil || group.Name() != path[len(path)-1] {
+		return
+	}
+
+	newPlanner := func(cmd *cobra.Command) *groupPlanner {
+		raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+		client := dbaasSdk.New(&sdkCoreConfig)
+		return &groupPlanner{
+			groups:     client.ParametersGroup(),
+			parameters: client.Parameters(),
+			engines:    client.Engines(),
+			output:     beautiful.NewOutput(raw),
+			resolve: func(cmd *cobra.Command, value string) (string, error) {
+				return resolve.Resolve(cmd, sdkCoreConfig, "dbaas parameters-group", idFlag, value)
+			},
+		}
+	}
+
+	group.AddCommand(exportCmd(newPlanner), diffCmd(newPlanner), applyCmd(newPlanner))
+}
+
+// exportCmd cria o 'parameters-group export', que escreve o grupo em YAML na saída padrão
+func exportCmd(newPlanner func(*cobra.Command) *groupPlanner) *cobra.Command {
+	manager := i18n.GetInstance()
+
+	cmd := &cobra.Command{
+		Use:   "export [id|name]",
+		Short: manager.T("cli.dbaas_pg.export.short"),
+		Long:  manager.T("cli.dbaas_pg.export.long"),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p := newPlanner(cmd)
+			id, err := p.groupID(cmd, args)
+			if err != nil {
+				return err
+			}
+			ctx := cmdutils.CommandContext(cmd)
+
+			group, err := p.groups.Get(ctx, id)
+			if err != nil {
+				return err
+			}
+			parameters, err := p.listParameters(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			var buffer bytes.Buffer
+			encoder := yaml.NewEncoder(&buffer)
+			encoder.SetIndent(2)
+			if err := encoder.Encode(exportSpec(group, parameters)); err != nil {
+				return err
+			}
+			if err := encoder.Close(); err != nil {
+				return err
+			}
+			fmt.Print(buffer.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(idFlag, "i", "", manager.T("cli.dbaas_pg.flag.id"))
+	return cmd
+}
+
+// diffCmd cria o 'parameters-group diff', que mostra o que o apply mudaria
+func diffCmd(newPlanner func(*cobra.Command) *groupPlanner) *cobra.Command {
+	manager := i18n.GetInstance()
+
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "diff [id|name]",
+		Short: manager.T("cli.dbaas_pg.diff.short"),
+		Long:  manager.T("cli.dbaas_pg.diff.long"),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p := newPlanner(cmd)
+			id, err := p.groupID(cmd, args)
+			if err != nil {
+				return err
+			}
+			group, changes, err := p.plan(cmdutils.CommandContext(cmd), id, file)
+			if err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				p.output.PrintSuccess(manager.T("cli.dbaas_pg.no_changes", group.Name))
+				return nil
+			}
+			p.printPlan(changes)
+			p.output.PrintInfo(manager.TN("cli.dbaas_pg.summary", len(changes)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(idFlag, "i", "", manager.T("cli.dbaas_pg.flag.id"))
+	cmd.Flags().StringVarP(&file, "file", "f", "", manager.T("cli.dbaas_pg.flag.file"))
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// applyCmd cria o 'parameters-group apply', que cria, altera e remove
+// parâmetros até o grupo ficar igual ao arquivo
+func applyCmd(newPlanner func(*cobra.Command) *groupPlanner) *cobra.Command {
+	manager := i18n.GetInstance()
+
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "apply [id|name]",
+		Short: manager.T("cli.dbaas_pg.apply.short"),
+		Long:  manager.T("cli.dbaas_pg.apply.long"),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p := newPlanner(cmd)
+			id, err := p.groupID(cmd, args)
+			if err != nil {
+				return err
+			}
+			ctx := cmdutils.CommandContext(cmd)
+
+			group, changes, err := p.plan(ctx, id, file)
+			if err != nil {
+				return err
+			}
+			if group.Type == dbaasSdk.ParameterGroupTypeSystem {
+				return fmt.Errorf(manager.T("cli.dbaas_pg.system_group"), group.Name)
+			}
+			if len(changes) == 0 {
+				p.output.PrintSuccess(manager.T("cli.dbaas_pg.no_changes", group.Name))
+				return nil
+			}
+			p.printPlan(changes)
+
+			if err := cmdutils.Confirm(cmd, manager.TN("cli.dbaas_pg.confirm", len(changes), i18n.Args{"group": group.Name}), "cli.dbaas.confirm_required", "cli.dbaas_pg.cancelled"); err != nil {
+				return err
+			}
+
+			restart := false
+			for _, c := range changes {
+				if err := c.apply(ctx); err != nil {
+					return fmt.Errorf(manager.T("cli.dbaas_pg.failed"), manager.T("cli.dbaas_pg.action."+c.action), c.name, err)
+				}
+				p.output.PrintSuccess(manager.T("cli.dbaas_pg.applied", manager.T("cli.dbaas_pg.action."+c.action), c.name))
+				restart = restart || c.restart
+			}
+			if restart {
+				p.output.PrintWarning(manager.T("cli.dbaas_pg.restart_required"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP(idFlag, "i", "", manager.T("cli.dbaas_pg.flag.id"))
+	cmd.Flags().StringVarP(&file, "file", "f", "", manager.T("cli.dbaas_pg.flag.file"))
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+// groupID retorna o ID do grupo, informado como argumento ou no --id. Os
+// dois aceitam o nome do grupo: o --id é resolvido antes do RunE e o
+// argumento, aqui.
+func (p *groupPlanner) groupID(cmd *cobra.Command, args []string) (string, error) {
+	manager := i18n.GetInstance()
+
+	id, _ := cmd.Flags().GetString(idFlag)
+	if len(args) == 1 {
+		resolved, err := p.resolve(cmd, args[0])
+		if err != nil {
+			return "", err
+		}
+		if id != "" && id != resolved {
+			return "", errors.New(manager.T("cli.dbaas_pg.id_conflict"))
+		}
+		id = resolved
+	}
+	if id == "" {
+		return "", errors.New(manager.T("cli.dbaas_pg.id_required"))
+	}
+	return id, nil
+}
+
+// plan lê o arquivo, valida os valores com os parâmetros do engine do grupo
+// e compara o resultado com o estado atual
+func (p *groupPlanner) plan(ctx context.Context, id, file string) (*dbaasSdk.ParameterGroupDetailResponse, []change, error) {
+	manager := i18n.GetInstance()
+
+	spec, err := loadGroupSpec(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	group, err := p.groups.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if spec.EngineID != "" && spec.EngineID != group.EngineID {
+		return nil, nil, fmt.Errorf(manager.T("cli.dbaas_pg.engine_mismatch"), file, spec.EngineID, group.Name, group.EngineID)
+	}
+
+	engine, err := p.listEngineParameters(ctx, group.EngineID)
+	if err != nil {
+		return nil, nil, err
+	}
+	desired, err := engine.validate(spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf(manager.T("cli.dbaas_pg.invalid_values"), file, err)
+	}
+
+	parameters, err := p.listParameters(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return group, p.diff(group, spec, desired, parameters, engine), nil
+}
+
+// diff compara os valores desejados com os parâmetros atuais do grupo.
+// Parâmetros novos vêm antes das alterações e das remoções, cada bloco em
+// ordem alfabética.
+func (p *groupPlanner) diff(group *dbaasSdk.ParameterGroupDetailResponse, spec *groupSpec, desired map[string]any, parameters []dbaasSdk.ParameterDetailResponse, engine engineParameters) []change {
+	changes := p.groupChanges(group, spec)
+
+	live := make(map[string]dbaasSdk.ParameterDetailResponse, len(parameters))
+	for _, parameter := range parameters {
+		live[parameter.Name] = parameter
+	}
+
+	var creates, updates, deletes []change
+	for _, name := range slices.Sorted(maps.Keys(desired)) {
+		value := desired[name]
+		current, exists := live[name]
+		if !exists {
+			creates = append(creates, change{
+				action:  actionCreate,
+				name:    name,
+				desired: formatValue(value),
+				restart: engine.restart(name),
+				apply: func(ctx context.Context) error {
+					_, err := p.parameters.Create(ctx, group.ID, dbaasSdk.ParameterCreateRequest{Name: name, Value: value})
+					return err
+				},
+			})
+			continue
+		}
+		if formatValue(current.Value) == formatValue(value) {
+			continue
+		}
+		updates = append(updates, change{
+			action:  actionUpdate,
+			name:    name,
+			current: formatValue(current.Value),
+			desired: formatValue(value),
+			restart: engine.restart(name),
+			apply: func(ctx context.Context) error {
+				_, err := p.parameters.Update(ctx, group.ID, current.ID, dbaasSdk.ParameterUpdateRequest{Value: value})
+				return err
+			},
+		})
+	}
+	for _, name := range slices.Sorted(maps.Keys(live)) {
+		if _, kept := desired[name]; kept {
+			continue
+		}
+		current := live[name]
+		deletes = append(deletes, change{
+			action:  actionDelete,
+			name:    name,
+			current: formatValue(current.Value),
+			restart: engine.restart(name),
+			apply: func(ctx context.Context) error {
+				return p.parameters.Delete(ctx, group.ID, current.ID)
+			},
+		})
+	}
+
+	changes = append(changes, creates...)
+	changes = append(changes, updates...)
+	return append(changes, deletes...)
+}
+
+// groupChanges compara o nome e a descrição do grupo, quando o arquivo os informa
+func (p *groupPlanner) groupChanges(group *dbaasSdk.ParameterGroupDetailResponse, spec *groupSpec) []change {
+	var changes []change
+	if spec.Name != "" && spec.Name != group.Name {
+		name := spec.Name
+		changes = append(changes, change{
+			action:  actionUpdate,
+			name:    "name",
+			group:   true,
+			current: group.Name,
+			desired: name,
+			apply: func(ctx context.Context) error {
+				_, err := p.groups.Update(ctx, group.ID, dbaasSdk.ParameterGroupUpdateRequest{Name: &name})
+				return err
+			},
+		})
+	}
+	if spec.Description != nil && *spec.Description != cmdutils.Deref(group.Description) {
+		description := *spec.Description
+		changes = append(changes, change{
+			action:  actionUpdate,
+			name:    "description",
+			group:   true,
+			current: cmdutils.Deref(group.Description),
+			desired: description,
+			apply: func(ctx context.Context) error {
+				_, err := p.groups.Update(ctx, group.ID, dbaasSdk.ParameterGroupUpdateRequest{Description: &description})
+				return err
+			},
+		})
+	}
+	return changes
+}
+
+// printPlan mostra as mudanças no formato de tabela, indicando as que só
+// valem depois de reiniciar as instâncias
+func (p *groupPlanner) printPlan(changes []change) {
+	manager := i18n.GetInstance()
+
+	rows := make([][]string, len(changes))
+	for i, c := range changes {
+		effect := manager.T("cli.dbaas_pg.effect.dynamic")
+		switch {
+		case c.group:
+			effect = "-"
+		case c.restart:
+			effect = manager.T("cli.dbaas_pg.effect.restart")
+		}
+		rows[i] = []string{manager.T("cli.dbaas_pg.action." + c.action), c.name, valueOr(c.current, "-"), valueOr(c.desired, "-"), effect}
+	}
+	p.output.PrintTable([]string{
+		manager.T("cli.dbaas_pg.column.action"),
+		manager.T("cli.dbaas_pg.column.parameter"),
+		manager.T("cli.dbaas_pg.column.current"),
+		manager.T("cli.dbaas_pg.column.desired"),
+		manager.T("cli.dbaas_pg.column.effect"),
+	}, rows)
+}
+
+// listParameters percorre as páginas de parâmetros do grupo
+func (p *groupPlanner) listParameters(ctx context.Context, groupID string) ([]dbaasSdk.ParameterDetailResponse, error) {
+	return cmdutils.Paged(func(limit, offset *int) ([]dbaasSdk.ParameterDetailResponse, error) {
+		return p.parameters.List(ctx, dbaasSdk.ListParametersOptions{ParameterGroupID: groupID, Limit: limit, Offset: offset})
+	})
+}
+
+// listEngineParameters percorre as páginas de parâmetros do engine
+func (p *groupPlanner) listEngineParameters(ctx context.Context, engineID string) (engineParameters, error) {
+	parameters, err := cmdutils.Paged(func(limit, offset *int) ([]dbaasSdk.EngineParameterDetail, error) {
+		return p.engines.ListEngineParameters(ctx, engineID, dbaasSdk.ListEngineParametersOptions{Limit: limit, Offset: offset})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newEngineParameters(parameters), nil
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,252 @@
+package dbaas
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	passwordFlag         = "password"
+	passwordStdinFlag    = "password-stdin"
+	passwordFileFlag     = "password-file"
+	generatePasswordFlag = "generate-password"
+	passwordOutputFlag   = "password-output"
+
+	generatedLength = 24
+)
+
+// Classes de caracteres da senha gerada. Os símbolos são só os não
+// reservados em URLs, que não exigem escape em strings de conexão nem
+// aspas no shell.
+const (
+	lowerChars  = "abcdefghijkmnopqrstuvwxyz"
+	upperChars  = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	digitChars  = "23456789"
+	symbolChars = "-_.~"
+)
+
+// PasswordCmd adiciona aos comandos de criação de instâncias e clusters do
+// DBaaS formas de informar a senha do administrador que não a deixam no
+// histórico do shell nem na lista de processos.
+func PasswordCmd(parent *cobra.Command) {
+	for _, path := range [][]string{
+		{"dbaas", "instances", "create"},
+		{"dbaas", "clusters", "create"},
+	} {
+		cmd, _, err := parent.Find(path)
+		if err != nil || cmd == nil || cmd.Name() != path[len(path)-1] || cmd.Flags().Lookup(passwordFlag) == nil {
+			continue
+		}
+		passwordInput(cmd)
+	}
+}
+
+// passwordInput registra as flags de senha no comando e preenche o
+// --password gerado antes do RunE original
+func passwordInput(cmd *cobra.Command) {
+	manager := i18n.GetInstance()
+
+	cmd.Flags().Bool(passwordStdinFlag, false, manager.T("cli.dbaas_password.flag.stdin"))
+	cmd.Flags().String(passwordFileFlag, "", manager.T("cli.dbaas_password.flag.file"))
+	cmd.Flags().Bool(generatePasswordFlag, false, manager.T("cli.dbaas_password.flag.generate"))
+	cmd.Flags().String(passwordOutputFlag, "", manager.T("cli.dbaas_password.flag.output"))
+	// O --password gerado deixa de ser obrigatório: sem nenhuma das flags a senha é pedida no terminal
+	cmd.Flags().SetAnnotation(passwordFlag, cobra.BashCompOneRequiredFlag, []string{"false"})
+	cmd.MarkFlagsMutuallyExclusive(passwordFlag, passwordStdinFlag, passwordFileFlag, generatePasswordFlag)
+	// No --interactive a senha é digitada ou gerada; ler a entrada padrão
+	// disputaria o terminal com as próprias perguntas
+	for _, name := range []string{passwordStdinFlag, passwordFileFlag, passwordOutputFlag} {
+		cmd.Flags().SetAnnotation(name, cmdutils.WizardSkipAnnotation, []string{"true"})
+	}
+
+	previous := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+		output := beautiful.NewOutput(raw)
+
+		generate, _ := cmd.Flags().GetBool(generatePasswordFlag)
+		outputPath, _ := cmd.Flags().GetString(passwordOutputFlag)
+		if outputPath != "" && !generate {
+			return errors.New(manager.T("cli.dbaas_password.output_without_generate"))
+		}
+
+		password, err := readPassword(cmd)
+		if err != nil {
+			return err
+		}
+		if password != "" {
+			if err := cmd.Flags().Set(passwordFlag, password); err != nil {
+				return err
+			}
+		}
+		if !generate {
+			return previous(cmd, args)
+		}
+
+		// A senha gerada é gravada antes da criação, para não se perder se o arquivo falhar depois
+		if outputPath != "" {
+			if err := cmdutils.WriteSecret(cmdutils.ExpandHome(outputPath), password); err != nil {
+				return err
+			}
+		}
+		if err := previous(cmd, args); err != nil {
+			return err
+		}
+		if outputPath != "" {
+			output.PrintSuccess(manager.T("cli.dbaas_password.saved", outputPath))
+			return nil
+		}
+		output.PrintWarning(manager.T("cli.dbaas_password.shown_once"))
+		fmt.Println(password)
+		return nil
+	}
+}
+
+// readPassword obtém a senha da fonte escolhida nas flags. Sem nenhuma
+// delas, a senha é pedida no terminal; um --password já informado é
+// mantido e resulta em uma senha vazia.
+func readPassword(cmd *cobra.Command) (string, error) {
+	manager := i18n.GetInstance()
+
+	if generate, _ := cmd.Flags().GetBool(generatePasswordFlag); generate {
+		return generatePassword()
+	}
+	if stdin, _ := cmd.Flags().GetBool(passwordStdinFlag); stdin {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+		return checkPassword(string(data), manager.T("cli.dbaas_password.source_stdin"))
+	}
+	if path, _ := cmd.Flags().GetString(passwordFileFlag); path != "" {
+		data, err := os.ReadFile(cmdutils.ExpandHome(path))
+		if err != nil {
+			return "", err
+		}
+		return checkPassword(string(data), path)
+	}
+	if cmd.Flags().Changed(passwordFlag) {
+		return "", nil
+	}
+
+	if !beautiful.IsInteractive() {
+		return "", errors.New(manager.T("cli.dbaas_password.required"))
+	}
+	return askPassword()
+}
+
+// checkPassword remove a quebra de linha final, deixada pelo echo e pelos
+// editores, e recusa uma senha vazia
+func checkPassword(value, source string) (string, error) {
+	password := strings.TrimRight(value, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf(i18n.GetInstance().T("cli.dbaas_password.empty"), source)
+	}
+	return password, nil
+}
+
+// askPassword pede a senha duas vezes sem exibi-la, repetindo até que as duas coincidam
+func askPassword() (string, error) {
+	manager := i18n.GetInstance()
+	output := beautiful.NewOutput(false)
+
+	for {
+		password, err := beautiful.AskSecret(manager.T("cli.dbaas_password.prompt"))
+		if err != nil {
+			return "", cancelled(err)
+		}
+		if password == "" {
+			output.PrintWarning(manager.T("cli.dbaas_password.prompt_empty"))
+			continue
+		}
+		confirmation, err := beautiful.AskSecret(manager.T("cli.dbaas_password.prompt_confirm"))
+		if err != nil {
+			return "", cancelled(err)
+		}
+		if password == confirmation {
+			return password, nil
+		}
+		output.PrintWarning(manager.T("cli.dbaas_password.prompt_mismatch"))
+	}
+}
+
+func cancelled(err error) error {
+	if errors.Is(err, beautiful.ErrCancelled) {
+		return errors.New(i18n.GetInstance().T("cli.dbaas_password.cancelled"))
+	}
+	return err
+}
+
+// generatePassword cria uma senha aleatória com letras maiúsculas e
+// minúsculas, dígitos e símbolos, atendendo às regras de complexidade do
+// MySQL e do PostgreSQL. Caracteres parecidos, como l, 1, O e 0, ficam de
+// fora, e a senha começa por uma letra para não ser lida como opção.
+func generatePassword() (string, error) {
+	classes := []string{lowerChars, upperChars, digitChars, symbolChars}
+	all := strings.Join(classes, "")
+
+	password := make([]byte, generatedLength)
+	for i := range password {
+		charset := all
+		if i == 0 {
+			charset = lowerChars + upperChars
+		}
+		c, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// Garante um caractere de cada classe em posições distintas depois da primeira
+	positions, err := randomPositions(len(classes), generatedLength)
+	if err != nil {
+		return "", err
+	}
+	for i, class := range classes {
+		c, err := randomChar(class)
+		if err != nil {
+			return "", err
+		}
+		password[positions[i]] = c
+	}
+	return string(password), nil
+}
+
+// randomPositions sorteia count posições distintas entre 1 e length-1
+func randomPositions(count, length int) ([]int, error) {
+	positions := make([]int, 0, count)
+	used := make(map[int]bool)
+	for len(positions) < count {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(length-1)))
+		if err != nil {
+			return nil, err
+		}
+		position := int(n.Int64()) + 1
+		if used[position] {
+			continue
+		}
+		used[position] = true
+		positions = append(positions, position)
+	}
+	return positions, nil
+}
+
+func randomChar(charset string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, err
+	}
+	return charset[n.Int64()], nil
+}
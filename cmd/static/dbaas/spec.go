@@ -0,0 +1,227 @@
+package dbaas
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gfcli/i18n"
+
+	dbaasSdk "github.com/MagaluCloud/mgc-sdk-go/dbaas"
+	"gopkg.in/yaml.v3"
+)
+
+// groupSpec descreve o estado desejado de um parameter group no arquivo do
+// 'export', 'diff' e 'apply'. Os parâmetros ausentes do arquivo voltam ao
+// padrão do engine. Arquivos JSON também são aceitos, já que JSON é um
+// subconjunto do YAML.
+type groupSpec struct {
+	Name        string         `yaml:"name,omitempty"`
+	Description *string        `yaml:"description,omitempty"`
+	EngineID    string         `yaml:"engine_id,omitempty"`
+	Parameters  map[string]any `yaml:"parameters"`
+}
+
+// rangePattern reconhece faixas como "0-100" ou "-1-65535" em allowed_values
+var rangePattern = regexp.MustCompile(`^\s*(-?[0-9.]+)\s*-\s*(-?[0-9.]+)\s*$`)
+
+// loadGroupSpec lê o arquivo de spec; "-" lê da entrada padrão
+func loadGroupSpec(path string) (*groupSpec, error) {
+	manager := i18n.GetInstance()
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var spec groupSpec
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf(manager.T("cli.dbaas_pg.invalid_spec"), path, err)
+	}
+	for name, value := range spec.Parameters {
+		switch value.(type) {
+		case string, bool, int, int64, uint64, float64:
+		default:
+			return nil, fmt.Errorf(manager.T("cli.dbaas_pg.invalid_spec"), path, fmt.Errorf(manager.T("cli.dbaas_pg.spec.scalar_required"), name))
+		}
+	}
+	return &spec, nil
+}
+
+// exportSpec monta o spec a partir do estado atual do parameter group
+func exportSpec(group *dbaasSdk.ParameterGroupDetailResponse, parameters []dbaasSdk.ParameterDetailResponse) groupSpec {
+	spec := groupSpec{
+		Name:        group.Name,
+		Description: group.Description,
+		EngineID:    group.EngineID,
+		Parameters:  make(map[string]any, len(parameters)),
+	}
+	for _, parameter := range parameters {
+		spec.Parameters[parameter.Name] = exportValue(parameter.Value)
+	}
+	return spec
+}
+
+// exportValue troca números inteiros recebidos como float64 por int64, para
+// que o YAML não os escreva em notação científica
+func exportValue(value any) any {
+	if number, ok := value.(float64); ok && number == float64(int64(number)) {
+		return int64(number)
+	}
+	return value
+}
+
+// formatValue formata um valor do spec ou da API para comparação e exibição
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// engineParameters indexa os parâmetros do engine pelo nome usado nos parameter groups
+type engineParameters map[string]dbaasSdk.EngineParameterDetail
+
+func newEngineParameters(parameters []dbaasSdk.EngineParameterDetail) engineParameters {
+	index := make(engineParameters, len(parameters))
+	for _, parameter := range parameters {
+		if parameter.ParameterName != "" {
+			index[parameter.ParameterName] = parameter
+		}
+		index[parameter.Name] = parameter
+	}
+	return index
+}
+
+// restart indica se a mudança do parâmetro só vale depois de reiniciar as instâncias
+func (e engineParameters) restart(name string) bool {
+	parameter, ok := e[name]
+	return ok && !parameter.Dynamic
+}
+
+// validate confere os parâmetros do spec com os do engine e retorna os
+// valores convertidos para o tipo esperado pela API
+func (e engineParameters) validate(spec *groupSpec) (map[string]any, error) {
+	manager := i18n.GetInstance()
+
+	var problems []string
+	values := make(map[string]any, len(spec.Parameters))
+	for _, name := range slices.Sorted(maps.Keys(spec.Parameters)) {
+		parameter, ok := e[name]
+		if !ok {
+			problems = append(problems, manager.T("cli.dbaas_pg.unknown_parameter", name))
+			continue
+		}
+		if !parameter.Modifiable {
+			problems = append(problems, manager.T("cli.dbaas_pg.not_modifiable", name))
+			continue
+		}
+		value, err := checkValue(parameter, spec.Parameters[name])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+			continue
+		}
+		values[name] = value
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "\n"))
+	}
+	return values, nil
+}
+
+// checkValue valida o valor pelo data_type e pelos allowed_values do
+// parâmetro. Números são convertidos, para que "200" entre como 200.
+func checkValue(parameter dbaasSdk.EngineParameterDetail, value any) (any, error) {
+	manager := i18n.GetInstance()
+	text := formatValue(value)
+	dataType := strings.ToLower(parameter.DataType)
+
+	var number float64
+	var numeric bool
+	switch {
+	case strings.Contains(dataType, "int"):
+		parsed, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf(manager.T("cli.dbaas_pg.expected_type"), text, parameter.DataType)
+		}
+		value, number, numeric = parsed, float64(parsed), true
+	case strings.Contains(dataType, "float"), strings.Contains(dataType, "double"),
+		strings.Contains(dataType, "decimal"), strings.Contains(dataType, "numeric"), strings.Contains(dataType, "real"):
+		parsed, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf(manager.T("cli.dbaas_pg.expected_type"), text, parameter.DataType)
+		}
+		value, number, numeric = parsed, parsed, true
+	case strings.Contains(dataType, "bool"):
+		switch strings.ToLower(text) {
+		case "true", "false", "on", "off", "1", "0", "yes", "no":
+		default:
+			return nil, fmt.Errorf(manager.T("cli.dbaas_pg.expected_type"), text, parameter.DataType)
+		}
+	}
+
+	if len(parameter.AllowedValues) == 0 {
+		return value, nil
+	}
+	if parameter.RangedValue {
+		low, high, ok := valueRange(parameter.AllowedValues)
+		if !ok || !numeric {
+			return value, nil
+		}
+		if number < low || number > high {
+			return nil, fmt.Errorf(manager.T("cli.dbaas_pg.out_of_range"), text, formatValue(low), formatValue(high))
+		}
+		return value, nil
+	}
+	for _, allowed := range parameter.AllowedValues {
+		if strings.EqualFold(allowed, text) {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf(manager.T("cli.dbaas_pg.not_allowed"), text, strings.Join(parameter.AllowedValues, ", "))
+}
+
+// valueRange interpreta a faixa de allowed_values, escrita como "min-max"
+// em um único item ou como dois itens, mínimo e máximo
+func valueRange(allowed []string) (float64, float64, bool) {
+	bounds := allowed
+	if len(allowed) == 1 {
+		match := rangePattern.FindStringSubmatch(allowed[0])
+		if match == nil {
+			return 0, 0, false
+		}
+		bounds = match[1:]
+	}
+	if len(bounds) != 2 {
+		return 0, 0, false
+	}
+	low, err := strconv.ParseFloat(strings.TrimSpace(bounds[0]), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	high, err := strconv.ParseFloat(strings.TrimSpace(bounds[1]), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return low, high, true
+}
@@ -0,0 +1,85 @@
+package explore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+func ExploreCmd(parent *cobra.Command) {
+	manager := i18n.GetInstance()
+	cmd := &cobra.Command{
+		Use:     "explore [file|-]",
+		Short:   manager.T("cli.explore.short"),
+		Long:    manager.T("cli.explore.long"),
+		Example: "  cli explore ~/.kube/config\n  cli virtual-machine instances list --raw | cli explore -",
+		Args:    cobra.MaximumNArgs(1),
+		GroupID: "other",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			output := beautiful.NewOutput(raw)
+
+			source := "-"
+			if len(args) == 1 {
+				source = args[0]
+			} else if beautiful.IsTerminal(os.Stdin) {
+				// Sem arquivo o documento viria de um stdin que só espera a digitação
+				return errors.New(manager.T("cli.explore.missing_document"))
+			}
+
+			data, err := readDocument(source)
+			if err != nil {
+				return fmt.Errorf(manager.T("cli.explore.read_error"), source, err)
+			}
+
+			document, err := cmdutils.DocumentToJSON(data)
+			if errors.Is(err, cmdutils.ErrEmptyDocument) {
+				return errors.New(manager.T("cli.explore.empty_document"))
+			}
+			if err != nil {
+				return fmt.Errorf(manager.T("cli.explore.invalid_document"), err)
+			}
+
+			// Quando o documento chega por stdin, o teclado é lido de /dev/tty
+			input := os.Stdin
+			if source == "-" {
+				tty, err := os.Open("/dev/tty")
+				if err != nil {
+					input = nil
+				} else {
+					defer tty.Close()
+					input = tty
+				}
+			}
+
+			if input == nil || !beautiful.IsTerminal(input) || !beautiful.IsTerminal(os.Stdout) {
+				fmt.Fprintln(os.Stderr, manager.T("cli.explore.not_interactive"))
+				beautiful.EnableExplorer(false)
+				output.PrintData(json.RawMessage(document))
+				return nil
+			}
+
+			explorer := beautiful.NewJSONExplorer(output)
+			explorer.SetInput(input)
+			return explorer.ExploreJSON(document)
+		},
+	}
+
+	parent.AddCommand(cmd)
+}
+
+// readDocument lê o documento do arquivo informado ou de stdin quando source é "-"
+func readDocument(source string) ([]byte, error) {
+	if source == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(source)
+}
@@ -0,0 +1,152 @@
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+	"gopkg.in/yaml.v3"
+)
+
+// contextPrefix forma os nomes previsíveis das entradas mescladas, como mgc-<cluster>
+const contextPrefix = "mgc-"
+
+// kubeconfig é um arquivo kubeconfig. Os campos desconhecidos ficam em Extra
+// para que a mescla não perca configurações do usuário, como preferences.
+type kubeconfig struct {
+	APIVersion     string                 `yaml:"apiVersion"`
+	Kind           string                 `yaml:"kind"`
+	Clusters       []entry                `yaml:"clusters"`
+	Contexts       []entry                `yaml:"contexts"`
+	Users          []entry                `yaml:"users"`
+	CurrentContext string                 `yaml:"current-context"`
+	Extra          map[string]interface{} `yaml:",inline"`
+}
+
+// entry é um item nomeado das listas clusters, contexts e users
+type entry struct {
+	Name  string                 `yaml:"name"`
+	Extra map[string]interface{} `yaml:",inline"`
+}
+
+// defaultPath retorna o kubeconfig usado pelo kubectl: o primeiro arquivo de
+// KUBECONFIG ou ~/.kube/config
+func defaultPath() (string, error) {
+	for _, path := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+		if path != "" {
+			return path, nil
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kube", "config"), nil
+}
+
+// fromSDK converte o kubeconfig retornado pela API
+func fromSDK(config *kubernetesSdk.KubeConfig) (*kubeconfig, error) {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var result kubeconfig
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// load lê um kubeconfig; um arquivo inexistente resulta em um kubeconfig vazio
+func load(path string) (*kubeconfig, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &kubeconfig{APIVersion: "v1", Kind: "Config"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var config kubeconfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	if config.APIVersion == "" {
+		config.APIVersion = "v1"
+	}
+	if config.Kind == "" {
+		config.Kind = "Config"
+	}
+	return &config, nil
+}
+
+// rename troca os nomes de clusters, users e contexts para mgc-<cluster> e
+// retorna o nome do context. Com mais de uma entrada, o nome original é
+// acrescentado para manter os nomes distintos.
+func (k *kubeconfig) rename(clusterName string) string {
+	base := contextPrefix + clusterName
+	newName := func(entries []entry, old string) string {
+		if len(entries) == 1 {
+			return base
+		}
+		return base + "-" + old
+	}
+
+	clusters := make(map[string]string)
+	for i, item := range k.Clusters {
+		clusters[item.Name] = newName(k.Clusters, item.Name)
+		k.Clusters[i].Name = clusters[item.Name]
+	}
+	users := make(map[string]string)
+	for i, item := range k.Users {
+		users[item.Name] = newName(k.Users, item.Name)
+		k.Users[i].Name = users[item.Name]
+	}
+
+	current := ""
+	for i, item := range k.Contexts {
+		name := newName(k.Contexts, item.Name)
+		if item.Name == k.CurrentContext || current == "" {
+			current = name
+		}
+		k.Contexts[i].Name = name
+		if context, ok := item.Extra["context"].(map[string]interface{}); ok {
+			if cluster, ok := context["cluster"].(string); ok && clusters[cluster] != "" {
+				context["cluster"] = clusters[cluster]
+			}
+			if user, ok := context["user"].(string); ok && users[user] != "" {
+				context["user"] = users[user]
+			}
+			// O SDK sempre preenche o namespace, mesmo quando a API não o envia
+			if namespace, ok := context["namespace"].(string); ok && namespace == "" {
+				delete(context, "namespace")
+			}
+		}
+	}
+	k.CurrentContext = current
+	return current
+}
+
+// merge insere as entradas de outro kubeconfig, substituindo as de mesmo nome
+func (k *kubeconfig) merge(other *kubeconfig) {
+	k.Clusters = upsert(k.Clusters, other.Clusters)
+	k.Users = upsert(k.Users, other.Users)
+	k.Contexts = upsert(k.Contexts, other.Contexts)
+}
+
+func upsert(entries, items []entry) []entry {
+	for _, item := range items {
+		replaced := false
+		for i := range entries {
+			if entries[i].Name == item.Name {
+				entries[i] = item
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			entries = append(entries, item)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,194 @@
+package kubeconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	writeFlag      = "write"
+	mergeFlag      = "merge"
+	allFlag        = "all"
+	setCurrentFlag = "set-current-context"
+	clusterIDFlag  = "cluster-id"
+)
+
+// cluster identifica um cluster cujo kubeconfig será mesclado
+type cluster struct {
+	id   string
+	name string
+}
+
+// KubeconfigCmd adiciona ao 'kubernetes clusters get-kube-config' as flags
+// para gravar o kubeconfig em um arquivo ou mesclá-lo no kubeconfig do usuário.
+func KubeconfigCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	manager := i18n.GetInstance()
+
+	path := []string{"kubernetes", "clusters", "get-kube-config"}
+	cmd, _, err := parent.Find(path)
+	if err != nil || cmd == nil || cmd.Name() != path[len(path)-1] {
+		return
+	}
+
+	cmd.Flags().String(writeFlag, "", manager.T("cli.kubeconfig.flag.write"))
+	cmd.Flags().Bool(mergeFlag, false, manager.T("cli.kubeconfig.flag.merge"))
+	cmd.Flags().Bool(allFlag, false, manager.T("cli.kubeconfig.flag.all"))
+	cmd.Flags().Bool(setCurrentFlag, false, manager.T("cli.kubeconfig.flag.set_current"))
+
+	previous := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		if !flags.Changed(writeFlag) && !flags.Changed(mergeFlag) && !flags.Changed(allFlag) && !flags.Changed(setCurrentFlag) {
+			return previous(cmd, args)
+		}
+
+		ctx := cmdutils.CommandContext(cmd)
+		return run(ctx, cmd, kubernetesSdk.New(&sdkCoreConfig).Clusters())
+	}
+}
+
+// run grava ou mescla o kubeconfig conforme as flags do comando
+func run(ctx context.Context, cmd *cobra.Command, service kubernetesSdk.ClusterService) error {
+	manager := i18n.GetInstance()
+	raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+	output := beautiful.NewOutput(raw)
+
+	path, _ := cmd.Flags().GetString(writeFlag)
+	merge, _ := cmd.Flags().GetBool(mergeFlag)
+	all, _ := cmd.Flags().GetBool(allFlag)
+	setCurrent, _ := cmd.Flags().GetBool(setCurrentFlag)
+	clusterID, _ := cmd.Flags().GetString(clusterIDFlag)
+
+	switch {
+	case all && clusterID != "":
+		return errors.New(manager.T("cli.kubeconfig.all_with_cluster"))
+	case !all && clusterID == "":
+		return errors.New(manager.T("cli.kubeconfig.cluster_required"))
+	case setCurrent && all:
+		return errors.New(manager.T("cli.kubeconfig.set_current_with_all"))
+	case setCurrent && !merge:
+		return errors.New(manager.T("cli.kubeconfig.set_current_requires_merge"))
+	}
+
+	// Sem --merge o kubeconfig é gravado como veio da API, substituindo o arquivo
+	if !merge && !all {
+		config, err := service.GetKubeConfig(ctx, clusterID)
+		if err != nil {
+			return err
+		}
+		data, err := encode(config)
+		if err != nil {
+			return err
+		}
+		if err := cmdutils.WriteFileAtomic(path, data, 0600); err != nil {
+			return err
+		}
+		output.PrintSuccess(manager.T("cli.kubeconfig.written", path))
+		return nil
+	}
+
+	if path == "" {
+		var err error
+		if path, err = defaultPath(); err != nil {
+			return err
+		}
+	}
+	target, err := load(path)
+	if err != nil {
+		return fmt.Errorf(manager.T("cli.kubeconfig.load_failed"), path, err)
+	}
+
+	var clusters []cluster
+	if all {
+		if clusters, err = listClusters(ctx, service); err != nil {
+			return err
+		}
+	} else {
+		// O nome do cluster forma o nome do context
+		item, err := service.Get(ctx, clusterID)
+		if err != nil {
+			return err
+		}
+		clusters = []cluster{{id: item.ID, name: item.Name}}
+	}
+
+	var contexts []string
+	for _, item := range clusters {
+		config, err := service.GetKubeConfig(ctx, item.id)
+		if err == nil {
+			var converted *kubeconfig
+			if converted, err = fromSDK(config); err == nil {
+				contexts = append(contexts, converted.rename(item.name))
+				target.merge(converted)
+				continue
+			}
+		}
+		// Com --all um cluster ainda em criação não impede os demais
+		if !all {
+			return err
+		}
+		output.PrintWarning(manager.T("cli.kubeconfig.skipped", item.name, err))
+	}
+	if len(contexts) == 0 {
+		return errors.New(manager.T("cli.kubeconfig.none_merged"))
+	}
+
+	if setCurrent {
+		target.CurrentContext = contexts[0]
+	}
+	data, err := encode(target)
+	if err != nil {
+		return err
+	}
+	if err := cmdutils.WriteFileAtomic(path, data, 0600); err != nil {
+		return err
+	}
+
+	for _, name := range contexts {
+		output.PrintSuccess(manager.T("cli.kubeconfig.merged", name, path))
+	}
+	if setCurrent {
+		output.PrintInfo(manager.T("cli.kubeconfig.current", contexts[0]))
+	}
+	return nil
+}
+
+// listClusters percorre todas as páginas da listagem de clusters
+func listClusters(ctx context.Context, service kubernetesSdk.ClusterService) ([]cluster, error) {
+	page, err := cmdutils.Paged(func(limit, offset *int) ([]kubernetesSdk.ClusterList, error) {
+		return service.List(ctx, kubernetesSdk.ListOptions{Limit: limit, Offset: offset})
+	})
+	if err != nil {
+		return nil, err
+	}
+	clusters := make([]cluster, 0, len(page))
+	for _, item := range page {
+		clusters = append(clusters, cluster{id: item.ID, name: item.Name})
+	}
+	return clusters, nil
+}
+
+// encode serializa em YAML com a indentação usada pelo kubectl
+func encode(value interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
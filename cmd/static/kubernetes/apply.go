@@ -0,0 +1,489 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+	"github.com/spf13/cobra"
+)
+
+// Ações de uma mudança do plano
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+	// actionManual é uma diferença que o SDK não aplica, mas que não impede as demais
+	actionManual = "manual"
+	// actionBlocked é uma diferença em um campo imutável, que impede o apply
+	actionBlocked = "blocked"
+)
+
+// change é uma linha do plano, com a operação que a aplica
+type change struct {
+	action   string
+	resource string
+	details  []string
+	apply    func(ctx context.Context) error
+}
+
+// applier reúne os serviços e a saída usados pelo 'apply'
+type applier struct {
+	clusters  kubernetesSdk.ClusterService
+	nodePools kubernetesSdk.NodePoolService
+	versions  kubernetesSdk.VersionService
+	flavors   kubernetesSdk.FlavorService
+	output    *beautiful.Output
+}
+
+// ApplyCmd adiciona o 'kubernetes clusters apply'.
+func ApplyCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	manager := i18n.GetInstance()
+
+	clusters := clustersCmd(parent)
+	if clusters == nil {
+		return
+	}
+
+	var file, clusterID string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: manager.T("cli.k8s_apply.short"),
+		Long:  manager.T("cli.k8s_apply.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			client := kubernetesSdk.New(&sdkCoreConfig)
+			a := &applier{
+				clusters:  client.Clusters(),
+				nodePools: client.Nodepools(),
+				versions:  client.Versions(),
+				flavors:   client.Flavors(),
+				output:    beautiful.NewOutput(raw),
+			}
+			return a.run(cmdutils.CommandContext(cmd), cmd, file, clusterID, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", manager.T("cli.k8s_apply.flag.file"))
+	cmd.Flags().StringVarP(&clusterID, "cluster-id", "c", "", manager.T("cli.k8s_apply.flag.cluster_id"))
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, manager.T("cli.k8s_apply.flag.dry_run"))
+	cmd.MarkFlagRequired("file")
+
+	clusters.AddCommand(cmd)
+}
+
+// run compara o spec com o estado atual, mostra o plano e o aplica
+func (a *applier) run(ctx context.Context, cmd *cobra.Command, file, clusterID string, dryRun bool) error {
+	manager := i18n.GetInstance()
+
+	spec, err := loadSpec(file)
+	if err != nil {
+		return err
+	}
+	if err := a.validate(ctx, spec); err != nil {
+		return err
+	}
+
+	cluster, err := a.find(ctx, spec, clusterID)
+	if err != nil {
+		return err
+	}
+
+	var changes []change
+	if cluster == nil {
+		changes = []change{a.createCluster(spec)}
+	} else {
+		pools, err := a.nodePools.List(ctx, cluster.ID, kubernetesSdk.ListOptions{})
+		if err != nil {
+			return err
+		}
+		changes = a.diff(spec, cluster, pools)
+	}
+
+	if len(changes) == 0 {
+		a.output.PrintSuccess(manager.T("cli.k8s_apply.no_changes", spec.Name))
+		return nil
+	}
+	a.printPlan(changes)
+
+	var applicable int
+	for _, c := range changes {
+		switch c.action {
+		case actionBlocked:
+			return errors.New(manager.T("cli.k8s_apply.blocked"))
+		case actionManual:
+		default:
+			applicable++
+		}
+	}
+	if applicable == 0 || dryRun {
+		return nil
+	}
+
+	if err := cmdutils.Confirm(cmd, manager.TN("cli.k8s_apply.confirm", applicable, i18n.Args{"cluster": spec.Name}), "cli.kubernetes.confirm_required", "cli.k8s_apply.cancelled"); err != nil {
+		return err
+	}
+
+	for _, c := range changes {
+		if c.apply == nil {
+			continue
+		}
+		if err := c.apply(ctx); err != nil {
+			return fmt.Errorf(manager.T("cli.k8s_apply.failed"), manager.T("cli.k8s_apply.action."+c.action), c.resource, err)
+		}
+		a.output.PrintSuccess(manager.T("cli.k8s_apply.applied", manager.T("cli.k8s_apply.action."+c.action), c.resource))
+	}
+	return nil
+}
+
+// validate confere flavors e versão com as listas da API
+func (a *applier) validate(ctx context.Context, spec *clusterSpec) error {
+	manager := i18n.GetInstance()
+
+	flavors, err := a.flavors.List(ctx, kubernetesSdk.ListOptions{})
+	if err != nil {
+		return err
+	}
+	available := make(map[string]bool)
+	for _, flavor := range flavors.NodePool {
+		available[flavor.Name] = true
+	}
+
+	var problems []string
+	for _, pool := range spec.NodePools {
+		if !available[pool.Flavor] {
+			problems = append(problems, manager.T("cli.k8s_apply.unknown_flavor", pool.Flavor, pool.Name))
+		}
+	}
+
+	if spec.Version != "" {
+		versions, err := a.versions.List(ctx)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, version := range versions {
+			if sameVersion(version.Version, spec.Version) {
+				found = true
+				if version.Deprecated {
+					a.output.PrintWarning(manager.T("cli.k8s_upgrade.target_deprecated", spec.Version))
+				}
+			}
+		}
+		if !found {
+			problems = append(problems, manager.T("cli.k8s_apply.unknown_version", spec.Version))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// find retorna o cluster do spec pelo ID ou pelo nome; nil indica que ele será criado
+func (a *applier) find(ctx context.Context, spec *clusterSpec, clusterID string) (*kubernetesSdk.Cluster, error) {
+	manager := i18n.GetInstance()
+
+	if clusterID == "" {
+		clusterID = spec.ID
+	}
+	if clusterID == "" {
+		clusters, err := listClusters(ctx, a.clusters)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range clusters {
+			if item.Name != spec.Name {
+				continue
+			}
+			if clusterID != "" {
+				return nil, fmt.Errorf(manager.T("cli.k8s_apply.ambiguous_name"), spec.Name)
+			}
+			clusterID = item.ID
+		}
+		if clusterID == "" {
+			return nil, nil
+		}
+	}
+	return a.clusters.Get(ctx, clusterID)
+}
+
+// createCluster cria o cluster com todos os node pools do spec
+func (a *applier) createCluster(spec *clusterSpec) change {
+	manager := i18n.GetInstance()
+
+	req := kubernetesSdk.ClusterRequest{Name: spec.Name, AllowedCIDRs: spec.AllowedCIDRs}
+	if spec.Version != "" {
+		req.Version = &spec.Version
+	}
+	if spec.Description != "" {
+		req.Description = &spec.Description
+	}
+	if spec.ServicesIPv4CIDR != "" {
+		req.ServicesIpV4CIDR = &spec.ServicesIPv4CIDR
+	}
+	if spec.ClusterIPv4CIDR != "" {
+		req.ClusterIPv4CIDR = &spec.ClusterIPv4CIDR
+	}
+
+	pools := make([]kubernetesSdk.CreateNodePoolRequest, len(spec.NodePools))
+	details := []string{manager.T("cli.k8s_apply.detail.version", valueOr(spec.Version, manager.T("cli.k8s_apply.default")))}
+	for i, pool := range spec.NodePools {
+		pools[i] = pool.createRequest()
+		details = append(details, manager.T("cli.k8s_apply.detail.node_pool", pool.Name, pool.Flavor, pool.initialReplicas()))
+	}
+	req.NodePools = &pools
+
+	return change{
+		action:   actionCreate,
+		resource: manager.T("cli.k8s_apply.resource.cluster", spec.Name),
+		details:  details,
+		apply: func(ctx context.Context) error {
+			_, err := a.clusters.Create(ctx, req)
+			return err
+		},
+	}
+}
+
+// diff compara o spec com o cluster existente e os seus node pools
+func (a *applier) diff(spec *clusterSpec, cluster *kubernetesSdk.Cluster, pools []kubernetesSdk.NodePool) []change {
+	manager := i18n.GetInstance()
+	resource := manager.T("cli.k8s_apply.resource.cluster", cluster.Name)
+
+	var changes []change
+	if spec.Version != "" && !sameVersion(spec.Version, cluster.Version) {
+		changes = append(changes, change{
+			action:   actionManual,
+			resource: resource,
+			details:  []string{manager.T("cli.k8s_apply.detail.version_change", cluster.Version, spec.Version)},
+		})
+	}
+	if spec.Description != "" && spec.Description != cmdutils.Deref(cluster.Description) {
+		changes = append(changes, change{
+			action:   actionManual,
+			resource: resource,
+			details:  []string{manager.T("cli.k8s_apply.detail.description_change", cmdutils.Deref(cluster.Description), spec.Description)},
+		})
+	}
+
+	var immutable []string
+	if spec.ServicesIPv4CIDR != "" && spec.ServicesIPv4CIDR != cmdutils.Deref(cluster.ServicesIpV4CIDR) {
+		immutable = append(immutable, fmt.Sprintf("services_ipv4_cidr: %s → %s", cmdutils.Deref(cluster.ServicesIpV4CIDR), spec.ServicesIPv4CIDR))
+	}
+	if spec.ClusterIPv4CIDR != "" && spec.ClusterIPv4CIDR != cmdutils.Deref(cluster.ClusterIPv4CIDR) {
+		immutable = append(immutable, fmt.Sprintf("cluster_ipv4_cidr: %s → %s", cmdutils.Deref(cluster.ClusterIPv4CIDR), spec.ClusterIPv4CIDR))
+	}
+	if len(immutable) > 0 {
+		changes = append(changes, change{
+			action:   actionBlocked,
+			resource: resource,
+			details:  append(immutable, manager.T("cli.k8s_apply.detail.recreate_cluster")),
+		})
+	}
+
+	var liveCIDRs []string
+	if cluster.AllowedCIDRs != nil {
+		liveCIDRs = *cluster.AllowedCIDRs
+	}
+	if spec.AllowedCIDRs != nil && !sameSet(*spec.AllowedCIDRs, liveCIDRs) {
+		cidrs := *spec.AllowedCIDRs
+		changes = append(changes, change{
+			action:   actionUpdate,
+			resource: resource,
+			details:  []string{fmt.Sprintf("allowed_cidrs: %s → %s", formatList(liveCIDRs), formatList(cidrs))},
+			apply: func(ctx context.Context) error {
+				_, err := a.clusters.Update(ctx, cluster.ID, kubernetesSdk.AllowedCIDRsUpdateRequest{AllowedCIDRs: cidrs})
+				return err
+			},
+		})
+	}
+
+	// Node pools novos são criados antes de remover os antigos, para não reduzir a capacidade
+	live := make(map[string]kubernetesSdk.NodePool)
+	for _, pool := range pools {
+		live[pool.Name] = pool
+	}
+	var creates, updates, deletes []change
+	for _, desired := range spec.NodePools {
+		current, exists := live[desired.Name]
+		if !exists {
+			creates = append(creates, a.createNodePool(cluster.ID, desired))
+			continue
+		}
+		delete(live, desired.Name)
+		if c, changed := a.updateNodePool(cluster.ID, desired, current); changed {
+			updates = append(updates, c)
+		}
+	}
+	for _, pool := range pools {
+		if _, removed := live[pool.Name]; removed {
+			deletes = append(deletes, a.deleteNodePool(cluster.ID, pool))
+		}
+	}
+
+	changes = append(changes, creates...)
+	changes = append(changes, updates...)
+	return append(changes, deletes...)
+}
+
+func (a *applier) createNodePool(clusterID string, desired nodePoolSpec) change {
+	manager := i18n.GetInstance()
+	req := desired.createRequest()
+	return change{
+		action:   actionCreate,
+		resource: manager.T("cli.k8s_apply.resource.node_pool", desired.Name),
+		details:  []string{manager.T("cli.k8s_apply.detail.node_pool", desired.Name, desired.Flavor, desired.initialReplicas())},
+		apply: func(ctx context.Context) error {
+			_, err := a.nodePools.Create(ctx, clusterID, req)
+			return err
+		},
+	}
+}
+
+func (a *applier) deleteNodePool(clusterID string, pool kubernetesSdk.NodePool) change {
+	manager := i18n.GetInstance()
+	return change{
+		action:   actionDelete,
+		resource: manager.T("cli.k8s_apply.resource.node_pool", pool.Name),
+		details:  []string{manager.T("cli.k8s_apply.detail.node_pool", pool.Name, nodePoolFlavor(pool), pool.Replicas)},
+		apply: func(ctx context.Context) error {
+			return a.nodePools.Delete(ctx, clusterID, pool.ID)
+		},
+	}
+}
+
+// updateNodePool compara um node pool existente com o spec. Réplicas e
+// autoscale são atualizados; os demais campos só mudam recriando o node pool.
+func (a *applier) updateNodePool(clusterID string, desired nodePoolSpec, current kubernetesSdk.NodePool) (change, bool) {
+	manager := i18n.GetInstance()
+	resource := manager.T("cli.k8s_apply.resource.node_pool", desired.Name)
+
+	var immutable []string
+	if flavor := nodePoolFlavor(current); desired.Flavor != flavor {
+		immutable = append(immutable, fmt.Sprintf("flavor: %s → %s", flavor, desired.Flavor))
+	}
+	if desired.AvailabilityZones != nil && !sameSet(desired.AvailabilityZones, derefSlice(current.AvailabilityZones)) {
+		immutable = append(immutable, fmt.Sprintf("availability_zones: %s → %s", formatList(derefSlice(current.AvailabilityZones)), formatList(desired.AvailabilityZones)))
+	}
+	if desired.Tags != nil && !sameSet(desired.Tags, derefSlice(current.Tags)) {
+		immutable = append(immutable, fmt.Sprintf("tags: %s → %s", formatList(derefSlice(current.Tags)), formatList(desired.Tags)))
+	}
+	if desired.Taints != nil {
+		var taints []kubernetesSdk.Taint
+		if current.Taints != nil {
+			taints = *current.Taints
+		}
+		if !sameSet(formatTaints(desired.Taints), formatTaints(taints)) {
+			immutable = append(immutable, fmt.Sprintf("taints: %s → %s", formatList(formatTaints(taints)), formatList(formatTaints(desired.Taints))))
+		}
+	}
+	if desired.MaxPodsPerNode != nil && (current.MaxPodsPerNode == nil || *current.MaxPodsPerNode != *desired.MaxPodsPerNode) {
+		immutable = append(immutable, fmt.Sprintf("max_pods_per_node: %s → %d", intOr(current.MaxPodsPerNode), *desired.MaxPodsPerNode))
+	}
+	if len(immutable) > 0 {
+		return change{
+			action:   actionBlocked,
+			resource: resource,
+			details:  append(immutable, manager.T("cli.k8s_apply.detail.rename_pool")),
+		}, true
+	}
+
+	var details []string
+	req := kubernetesSdk.PatchNodePoolRequest{}
+	if desired.AutoScale != nil {
+		var currentMin, currentMax *int
+		if current.AutoScale != nil {
+			currentMin, currentMax = current.AutoScale.MinReplicas, current.AutoScale.MaxReplicas
+		}
+		if currentMin == nil || currentMax == nil || *currentMin != desired.AutoScale.MinReplicas || *currentMax != desired.AutoScale.MaxReplicas {
+			details = append(details, fmt.Sprintf("auto_scale: %s-%s → %d-%d", intOr(currentMin), intOr(currentMax), desired.AutoScale.MinReplicas, desired.AutoScale.MaxReplicas))
+			req.AutoScale = desired.AutoScale.sdk()
+		}
+	} else if desired.Replicas != current.Replicas {
+		// Com autoscale as réplicas são controladas pelo autoscaler
+		replicas := desired.Replicas
+		details = append(details, fmt.Sprintf("replicas: %d → %d", current.Replicas, replicas))
+		req.Replicas = &replicas
+	}
+	if len(details) == 0 {
+		return change{}, false
+	}
+
+	return change{
+		action:   actionUpdate,
+		resource: resource,
+		details:  details,
+		apply: func(ctx context.Context) error {
+			_, err := a.nodePools.Update(ctx, clusterID, current.ID, req)
+			return err
+		},
+	}, true
+}
+
+// printPlan mostra as mudanças no formato de tabela
+func (a *applier) printPlan(changes []change) {
+	manager := i18n.GetInstance()
+
+	rows := make([][]string, 0, len(changes))
+	for _, c := range changes {
+		for i, detail := range c.details {
+			if i == 0 {
+				rows = append(rows, []string{manager.T("cli.k8s_apply.action." + c.action), c.resource, detail})
+				continue
+			}
+			rows = append(rows, []string{"", "", detail})
+		}
+	}
+	a.output.PrintTable([]string{
+		manager.T("cli.k8s_apply.column.action"),
+		manager.T("cli.k8s_apply.column.resource"),
+		manager.T("cli.k8s_apply.column.change"),
+	}, rows)
+
+	for _, c := range changes {
+		if c.action == actionManual {
+			a.output.PrintHint(manager.T("cli.k8s_apply.manual_title"), manager.T("cli.k8s_apply.manual_hint"))
+			break
+		}
+	}
+}
+
+// nodePoolFlavor retorna o flavor do node pool, que algumas respostas trazem
+// apenas no template da instância
+func nodePoolFlavor(pool kubernetesSdk.NodePool) string {
+	if pool.Flavor != "" {
+		return pool.Flavor
+	}
+	return pool.InstanceTemplate.Flavor.Name
+}
+
+func derefSlice(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
+func intOr(value *int) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprint(*value)
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,26 @@
+package kubernetes
+
+import (
+	"context"
+
+	cmdutils "gfcli/cmd_utils"
+
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+	"github.com/spf13/cobra"
+)
+
+// clustersCmd encontra o grupo gerado 'kubernetes clusters'
+func clustersCmd(parent *cobra.Command) *cobra.Command {
+	cmd, _, err := parent.Find([]string{"kubernetes", "clusters"})
+	if err != nil || cmd == nil || cmd.Name() != "clusters" {
+		return nil
+	}
+	return cmd
+}
+
+// listClusters percorre todas as páginas da listagem de clusters
+func listClusters(ctx context.Context, service kubernetesSdk.ClusterService) ([]kubernetesSdk.ClusterList, error) {
+	return cmdutils.Paged(func(limit, offset *int) ([]kubernetesSdk.ClusterList, error) {
+		return service.List(ctx, kubernetesSdk.ListOptions{Limit: limit, Offset: offset})
+	})
+}
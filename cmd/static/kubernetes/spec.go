@@ -0,0 +1,163 @@
+package kubernetes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"gfcli/i18n"
+
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+	"gopkg.in/yaml.v3"
+)
+
+// clusterSpec descreve o estado desejado de um cluster no arquivo do 'apply'.
+// Arquivos JSON também são aceitos, já que JSON é um subconjunto do YAML.
+type clusterSpec struct {
+	ID               string         `yaml:"id"`
+	Name             string         `yaml:"name"`
+	Description      string         `yaml:"description"`
+	Version          string         `yaml:"version"`
+	ServicesIPv4CIDR string         `yaml:"services_ipv4_cidr"`
+	ClusterIPv4CIDR  string         `yaml:"cluster_ipv4_cidr"`
+	AllowedCIDRs     *[]string      `yaml:"allowed_cidrs"`
+	NodePools        []nodePoolSpec `yaml:"node_pools"`
+}
+
+// nodePoolSpec descreve um node pool; o nome liga o spec ao node pool existente
+type nodePoolSpec struct {
+	Name              string                `yaml:"name"`
+	Flavor            string                `yaml:"flavor"`
+	Replicas          int                   `yaml:"replicas"`
+	AutoScale         *autoScaleSpec        `yaml:"auto_scale"`
+	MaxPodsPerNode    *int                  `yaml:"max_pods_per_node"`
+	AvailabilityZones []string              `yaml:"availability_zones"`
+	Tags              []string              `yaml:"tags"`
+	Taints            []kubernetesSdk.Taint `yaml:"taints"`
+}
+
+type autoScaleSpec struct {
+	MinReplicas int `yaml:"min_replicas"`
+	MaxReplicas int `yaml:"max_replicas"`
+}
+
+// loadSpec lê e valida o arquivo de spec; "-" lê da entrada padrão
+func loadSpec(path string) (*clusterSpec, error) {
+	manager := i18n.GetInstance()
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var spec clusterSpec
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf(manager.T("cli.k8s_apply.invalid_spec"), path, err)
+	}
+
+	var problems []string
+	if spec.Name == "" {
+		problems = append(problems, manager.T("cli.k8s_apply.spec.name_required"))
+	}
+	if len(spec.NodePools) == 0 {
+		problems = append(problems, manager.T("cli.k8s_apply.spec.node_pools_required"))
+	}
+	seen := make(map[string]bool)
+	for i, pool := range spec.NodePools {
+		label := pool.Name
+		if label == "" {
+			label = fmt.Sprintf("#%d", i+1)
+			problems = append(problems, manager.T("cli.k8s_apply.spec.pool_name_required", label))
+		}
+		if seen[pool.Name] {
+			problems = append(problems, manager.T("cli.k8s_apply.spec.pool_duplicated", label))
+		}
+		seen[pool.Name] = true
+		if pool.Flavor == "" {
+			problems = append(problems, manager.T("cli.k8s_apply.spec.flavor_required", label))
+		}
+		if pool.Replicas < 0 || (pool.Replicas == 0 && pool.AutoScale == nil) {
+			problems = append(problems, manager.T("cli.k8s_apply.spec.replicas_invalid", label))
+		}
+		if pool.AutoScale != nil && (pool.AutoScale.MinReplicas > pool.AutoScale.MaxReplicas || pool.AutoScale.MinReplicas < 0) {
+			problems = append(problems, manager.T("cli.k8s_apply.spec.auto_scale_invalid", label))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf(manager.T("cli.k8s_apply.invalid_spec"), path, errors.New(strings.Join(problems, "; ")))
+	}
+	return &spec, nil
+}
+
+// initialReplicas retorna as réplicas na criação; com autoscale e sem
+// réplicas no spec, o node pool começa com o mínimo do autoscale
+func (p nodePoolSpec) initialReplicas() int {
+	if p.Replicas == 0 && p.AutoScale != nil {
+		return p.AutoScale.MinReplicas
+	}
+	return p.Replicas
+}
+
+// createRequest monta a criação de um node pool a partir do spec
+func (p nodePoolSpec) createRequest() kubernetesSdk.CreateNodePoolRequest {
+	req := kubernetesSdk.CreateNodePoolRequest{
+		Name:           p.Name,
+		Flavor:         p.Flavor,
+		Replicas:       p.initialReplicas(),
+		MaxPodsPerNode: p.MaxPodsPerNode,
+	}
+	if len(p.Tags) > 0 {
+		req.Tags = &p.Tags
+	}
+	if len(p.Taints) > 0 {
+		req.Taints = &p.Taints
+	}
+	if len(p.AvailabilityZones) > 0 {
+		req.AvailabilityZones = &p.AvailabilityZones
+	}
+	if p.AutoScale != nil {
+		req.AutoScale = p.AutoScale.sdk()
+	}
+	return req
+}
+
+func (a *autoScaleSpec) sdk() *kubernetesSdk.AutoScale {
+	minReplicas, maxReplicas := a.MinReplicas, a.MaxReplicas
+	return &kubernetesSdk.AutoScale{MinReplicas: &minReplicas, MaxReplicas: &maxReplicas}
+}
+
+// sameSet compara listas ignorando a ordem
+func sameSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	sort.Strings(a)
+	sort.Strings(b)
+	return slices.Equal(a, b)
+}
+
+func formatTaints(taints []kubernetesSdk.Taint) []string {
+	result := make([]string, len(taints))
+	for i, taint := range taints {
+		result[i] = fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+	}
+	return result
+}
+
+func formatList(values []string) string {
+	if len(values) == 0 {
+		return "[]"
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
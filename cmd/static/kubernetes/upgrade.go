@@ -0,0 +1,318 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+	"github.com/spf13/cobra"
+)
+
+// pollInterval é o intervalo entre as consultas ao cluster com --wait
+const pollInterval = 10 * time.Second
+
+// upgrade reúne os serviços e a saída usados pelo comando
+type upgrade struct {
+	clusters  kubernetesSdk.ClusterService
+	nodePools kubernetesSdk.NodePoolService
+	versions  kubernetesSdk.VersionService
+	output    *beautiful.Output
+}
+
+// UpgradeCmd adiciona o 'kubernetes clusters upgrade'.
+func UpgradeCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	manager := i18n.GetInstance()
+
+	clusters := clustersCmd(parent)
+	if clusters == nil {
+		return
+	}
+
+	var clusterID, version string
+	var wait bool
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: manager.T("cli.k8s_upgrade.short"),
+		Long:  manager.T("cli.k8s_upgrade.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			client := kubernetesSdk.New(&sdkCoreConfig)
+			u := &upgrade{
+				clusters:  client.Clusters(),
+				nodePools: client.Nodepools(),
+				versions:  client.Versions(),
+				output:    beautiful.NewOutput(raw),
+			}
+			return u.run(cmdutils.CommandContext(cmd), clusterID, version, wait, timeout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&clusterID, "cluster-id", "c", "", manager.T("cli.k8s_upgrade.flag.cluster_id"))
+	cmd.Flags().StringVar(&version, "version", "", manager.T("cli.k8s_upgrade.flag.version"))
+	cmd.Flags().BoolVar(&wait, "wait", false, manager.T("cli.k8s_upgrade.flag.wait"))
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, manager.T("cli.k8s_upgrade.flag.timeout"))
+	cmd.MarkFlagRequired("cluster-id")
+
+	clusters.AddCommand(cmd)
+}
+
+// run mostra o plano de upgrade e, com --version, confere se o cluster pode
+// subir para a versão. O SDK ainda não troca a versão do cluster, então o
+// upgrade é iniciado pelo console e o --wait o acompanha.
+func (u *upgrade) run(ctx context.Context, clusterID, version string, wait bool, timeout time.Duration) error {
+	manager := i18n.GetInstance()
+
+	cluster, err := u.clusters.Get(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+	versions, err := u.versions.List(ctx)
+	if err != nil {
+		return err
+	}
+	p, err := newPlan(cluster.Version, versions)
+	if err != nil {
+		return err
+	}
+	u.printPlan(cluster, p)
+
+	// Sem alvo, o skew é verificado contra a versão atual
+	reference := p.current
+	var target *release
+	if version != "" {
+		found, ok := p.find(version)
+		if !ok {
+			return fmt.Errorf(manager.T("cli.k8s_upgrade.version_not_available"), version, cluster.Version)
+		}
+		steps, missing := p.path(found)
+		switch {
+		case missing != "":
+			return fmt.Errorf(manager.T("cli.k8s_upgrade.missing_minor"), missing)
+		case len(steps) > 1:
+			return fmt.Errorf(manager.T("cli.k8s_upgrade.one_minor"), steps[0].version.raw, pathString(steps))
+		}
+		if found.deprecated {
+			u.output.PrintWarning(manager.T("cli.k8s_upgrade.target_deprecated", found.version.raw))
+		}
+		target = &found
+		reference = found.version
+	}
+
+	pools, err := u.nodePools.List(ctx, clusterID, kubernetesSdk.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if blocked := u.checkSkew(ctx, clusterID, pools, reference); len(blocked) > 0 {
+		return fmt.Errorf(manager.T("cli.k8s_upgrade.skew_blocked"), strings.Join(blocked, ", "), reference.raw)
+	}
+
+	if target == nil {
+		if !wait {
+			u.output.PrintInfo(manager.T("cli.k8s_upgrade.plan_only"))
+			return nil
+		}
+		return u.wait(ctx, clusterID, "", timeout)
+	}
+
+	u.output.PrintInfo(manager.T("cli.k8s_upgrade.start_in_console", cluster.Name, target.version.raw))
+	if !wait {
+		return nil
+	}
+	return u.wait(ctx, clusterID, target.version.raw, timeout)
+}
+
+// printPlan mostra a versão atual e as versões para as quais o cluster pode subir
+func (u *upgrade) printPlan(cluster *kubernetesSdk.Cluster, p *plan) {
+	manager := i18n.GetInstance()
+
+	u.output.PrintInfo(manager.T("cli.k8s_upgrade.current", cluster.Name, cluster.Version))
+	switch {
+	case p.eol:
+		u.output.PrintWarning(manager.T("cli.k8s_upgrade.current_eol", cluster.Version))
+	case p.deprecated:
+		u.output.PrintWarning(manager.T("cli.k8s_upgrade.current_deprecated", cluster.Version))
+	}
+
+	if len(p.newer) == 0 {
+		u.output.PrintSuccess(manager.T("cli.k8s_upgrade.up_to_date"))
+		return
+	}
+
+	rows := make([][]string, len(p.newer))
+	for i, item := range p.newer {
+		status := ""
+		if item.deprecated {
+			status = manager.T("cli.k8s_upgrade.deprecated")
+		}
+
+		steps, missing := p.path(item)
+		upgrade := manager.T("cli.k8s_upgrade.direct")
+		switch {
+		case missing != "":
+			upgrade = manager.T("cli.k8s_upgrade.unreachable", missing)
+		case len(steps) > 1:
+			upgrade = manager.T("cli.k8s_upgrade.via", pathString(steps[:len(steps)-1]))
+		}
+		rows[i] = []string{item.version.raw, status, upgrade}
+	}
+	u.output.PrintTable([]string{
+		manager.T("cli.k8s_upgrade.column.version"),
+		manager.T("cli.k8s_upgrade.column.status"),
+		manager.T("cli.k8s_upgrade.column.upgrade"),
+	}, rows)
+
+	if steps, ok := p.recommended(); ok {
+		u.output.PrintInfo(manager.T("cli.k8s_upgrade.recommended", pathString(steps)))
+	}
+}
+
+// checkSkew compara o kubelet dos nós de cada node pool com a versão do
+// control plane e retorna os node pools que ficariam fora da política
+func (u *upgrade) checkSkew(ctx context.Context, clusterID string, pools []kubernetesSdk.NodePool, reference semver) []string {
+	manager := i18n.GetInstance()
+
+	var blocked []string
+	rows := make([][]string, 0, len(pools))
+	for _, pool := range pools {
+		nodes, err := u.nodePools.Nodes(ctx, clusterID, pool.ID)
+		if err != nil {
+			rows = append(rows, []string{pool.Name, "-", "-", err.Error()})
+			continue
+		}
+
+		kubelets := make(map[string]bool)
+		oldest, newest := 0, 0
+		for _, node := range nodes {
+			kubelets[node.Infrastructure.KubeletVersion] = true
+			v, err := parseVersion(node.Infrastructure.KubeletVersion)
+			if err != nil {
+				continue
+			}
+			oldest = max(oldest, v.minorsBehind(reference))
+			newest = min(newest, v.minorsBehind(reference))
+		}
+
+		status := manager.T("cli.k8s_upgrade.skew_ok", oldest)
+		switch {
+		case oldest > maxKubeletSkew:
+			status = manager.T("cli.k8s_upgrade.skew_too_old", oldest, maxKubeletSkew)
+			blocked = append(blocked, pool.Name)
+		case newest < 0:
+			status = manager.T("cli.k8s_upgrade.skew_too_new")
+			blocked = append(blocked, pool.Name)
+		}
+		rows = append(rows, []string{pool.Name, fmt.Sprint(len(nodes)), strings.Join(sortedKeys(kubelets), ", "), status})
+	}
+
+	if len(rows) > 0 {
+		u.output.PrintTable([]string{
+			manager.T("cli.k8s_upgrade.column.node_pool"),
+			manager.T("cli.k8s_upgrade.column.nodes"),
+			manager.T("cli.k8s_upgrade.column.kubelet"),
+			manager.T("cli.k8s_upgrade.column.skew", reference.raw),
+		}, rows)
+	}
+	return blocked
+}
+
+// wait acompanha o cluster até ele voltar a rodar na versão esperada e então
+// mostra o estado dos nós de cada node pool
+func (u *upgrade) wait(ctx context.Context, clusterID, version string, timeout time.Duration) error {
+	manager := i18n.GetInstance()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	last := ""
+	for {
+		cluster, err := u.clusters.Get(ctx, clusterID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf(manager.T("cli.k8s_upgrade.timeout"), timeout)
+			}
+			return err
+		}
+
+		state := ""
+		if cluster.Status != nil {
+			state = cluster.Status.State
+		}
+		if current := state + " " + cluster.Version; current != last {
+			u.output.PrintInfo(manager.T("cli.k8s_upgrade.status", state, cluster.Version))
+			last = current
+		}
+		if strings.EqualFold(state, "running") && (version == "" || sameVersion(cluster.Version, version)) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf(manager.T("cli.k8s_upgrade.timeout"), timeout)
+		case <-time.After(pollInterval):
+		}
+	}
+
+	return u.reportNodes(ctx, clusterID)
+}
+
+// reportNodes mostra o estado e a versão do kubelet de cada nó
+func (u *upgrade) reportNodes(ctx context.Context, clusterID string) error {
+	manager := i18n.GetInstance()
+
+	pools, err := u.nodePools.List(ctx, clusterID, kubernetesSdk.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, pool := range pools {
+		nodes, err := u.nodePools.Nodes(ctx, clusterID, pool.ID)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			rows = append(rows, []string{pool.Name, node.Name, node.Status.State, node.Infrastructure.KubeletVersion})
+		}
+	}
+	u.output.PrintTable([]string{
+		manager.T("cli.k8s_upgrade.column.node_pool"),
+		manager.T("cli.k8s_upgrade.column.node"),
+		manager.T("cli.k8s_upgrade.column.status"),
+		manager.T("cli.k8s_upgrade.column.kubelet"),
+	}, rows)
+	u.output.PrintSuccess(manager.T("cli.k8s_upgrade.done"))
+	return nil
+}
+
+func pathString(steps []release) string {
+	names := make([]string, len(steps))
+	for i, step := range steps {
+		names[i] = step.version.raw
+	}
+	return strings.Join(names, " → ")
+}
+
+func sameVersion(a, b string) bool {
+	va, errA := parseVersion(a)
+	vb, errB := parseVersion(b)
+	return errA == nil && errB == nil && va.compare(vb) == 0
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,154 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+)
+
+// maxKubeletSkew é quantas versões minor o kubelet pode estar atrás do
+// control plane, conforme a política de version skew do Kubernetes
+const maxKubeletSkew = 3
+
+// semver é uma versão do Kubernetes, como v1.30.2
+type semver struct {
+	raw                 string
+	major, minor, patch int
+}
+
+// parseVersion interpreta versões como v1.30.2 ou 1.30.2-mgc.1
+func parseVersion(raw string) (semver, error) {
+	core := strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) < 2 {
+		return semver{}, fmt.Errorf("invalid version %q", raw)
+	}
+
+	numbers := make([]int, 3)
+	for i := 0; i < len(parts) && i < 3; i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return semver{}, fmt.Errorf("invalid version %q", raw)
+		}
+		numbers[i] = n
+	}
+	return semver{raw: raw, major: numbers[0], minor: numbers[1], patch: numbers[2]}, nil
+}
+
+func (v semver) compare(other semver) int {
+	switch {
+	case v.major != other.major:
+		return v.major - other.major
+	case v.minor != other.minor:
+		return v.minor - other.minor
+	default:
+		return v.patch - other.patch
+	}
+}
+
+// minorsBehind retorna quantas versões minor v está atrás de other
+func (v semver) minorsBehind(other semver) int {
+	if v.major != other.major {
+		return (other.major-v.major)*1000 + other.minor - v.minor
+	}
+	return other.minor - v.minor
+}
+
+// release é uma versão oferecida pela API
+type release struct {
+	version    semver
+	deprecated bool
+}
+
+// plan descreve as versões disponíveis para um cluster
+type plan struct {
+	current semver
+	// eol indica que a versão atual não é mais oferecida pela API
+	eol        bool
+	deprecated bool
+	// newer são as versões mais novas que a atual, em ordem crescente
+	newer []release
+}
+
+func newPlan(current string, versions []kubernetesSdk.Version) (*plan, error) {
+	cur, err := parseVersion(current)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &plan{current: cur, eol: true}
+	for _, item := range versions {
+		v, err := parseVersion(item.Version)
+		if err != nil {
+			continue
+		}
+		switch c := v.compare(cur); {
+		case c == 0:
+			p.eol = false
+			p.deprecated = item.Deprecated
+		case c > 0:
+			p.newer = append(p.newer, release{version: v, deprecated: item.Deprecated})
+		}
+	}
+	sort.Slice(p.newer, func(i, j int) bool { return p.newer[i].version.compare(p.newer[j].version) < 0 })
+	return p, nil
+}
+
+// find procura uma versão mais nova que a atual
+func (p *plan) find(raw string) (release, bool) {
+	target, err := parseVersion(raw)
+	if err != nil {
+		return release{}, false
+	}
+	for _, item := range p.newer {
+		if item.version.compare(target) == 0 {
+			return item, true
+		}
+	}
+	return release{}, false
+}
+
+// path retorna as versões a aplicar até o alvo. O control plane sobe uma
+// versão minor por vez, então cada minor intermediária usa o seu patch mais
+// recente não depreciado. Quando falta uma minor intermediária, ela é retornada em missing.
+func (p *plan) path(target release) (steps []release, missing string) {
+	from := p.current
+	for from.major == target.version.major && from.minor < target.version.minor-1 {
+		next, found := release{}, false
+		for _, item := range p.newer {
+			// O patch mais recente da minor, evitando versões depreciadas quando possível
+			if item.version.major == from.major && item.version.minor == from.minor+1 && (!found || next.deprecated || !item.deprecated) {
+				next, found = item, true
+			}
+		}
+		if !found {
+			return nil, fmt.Sprintf("v%d.%d", from.major, from.minor+1)
+		}
+		steps = append(steps, next)
+		from = next.version
+	}
+	if from.major != target.version.major {
+		return nil, target.version.raw
+	}
+	return append(steps, target), ""
+}
+
+// recommended retorna o caminho até a versão mais recente, não depreciada e
+// alcançável, se houver
+func (p *plan) recommended() ([]release, bool) {
+	for i := len(p.newer) - 1; i >= 0; i-- {
+		if p.newer[i].deprecated {
+			continue
+		}
+		if steps, missing := p.path(p.newer[i]); missing == "" {
+			return steps, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,186 @@
+package resolve
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// namePrefix força a busca pelo nome, mesmo quando o valor parece um ID
+const namePrefix = "name:"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// resolver guarda os IDs já resolvidos, para que o mesmo nome não seja
+// buscado duas vezes na mesma execução
+type resolver struct {
+	cache map[string]string
+}
+
+// ResolveCmd faz as flags de ID dos comandos gerados aceitarem também o nome
+// do recurso, como 'name:web-01' ou só 'web-01' quando o nome é único. Deve
+// ser chamado depois de todas as outras extensões, para que a resolução
+// aconteça antes dos RunE que elas já envolveram.
+func ResolveCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	r := &resolver{cache: make(map[string]string)}
+	table := rules(sdkCoreConfig)
+
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+		if cmd.RunE == nil {
+			return
+		}
+		if flags := resolvable(cmd, table); len(flags) > 0 {
+			r.wrap(cmd, flags)
+		}
+	}
+	walk(parent)
+}
+
+// Resolve troca por um ID o nome recebido em um argumento posicional dos
+// comandos estáticos, com as mesmas regras das flags de ID. path e flag
+// escolhem a regra, como "virtual-machine instances" e "id".
+func Resolve(cmd *cobra.Command, sdkCoreConfig sdk.CoreClient, path, flag, value string) (string, error) {
+	res, ok := rules(sdkCoreConfig)[path][flag]
+	if !ok {
+		return value, nil
+	}
+	r := &resolver{cache: make(map[string]string)}
+	return r.resolve(cmd, res, value)
+}
+
+// resolvable associa as flags de ID do comando aos recursos que elas
+// referenciam. As regras do grupo do comando têm precedência sobre as do produto.
+func resolvable(cmd *cobra.Command, table map[string]map[string]resource) map[string]resource {
+	manager := i18n.GetInstance()
+
+	path := strings.Fields(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+	flags := make(map[string]resource)
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if flag.Value.Type() != "string" {
+			return
+		}
+		for size := len(path) - 1; size > 0; size-- {
+			res, ok := table[strings.Join(path[:size], " ")][flag.Name]
+			if !ok {
+				continue
+			}
+			flags[flag.Name] = res
+			if flag.Usage == "" {
+				flag.Usage = manager.T("cli.resolve.flag", manager.T("cli.resolve.resource."+res.noun))
+			}
+			return
+		}
+	})
+	return flags
+}
+
+// wrap troca os nomes pelos IDs antes de o comando ser executado
+func (r *resolver) wrap(cmd *cobra.Command, flags map[string]resource) {
+	// Recursos que dependem de outra flag, como um node pool do cluster,
+	// são resolvidos depois dela
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		first, second := flags[names[i]].parent == "", flags[names[j]].parent == ""
+		if first != second {
+			return first
+		}
+		return names[i] < names[j]
+	})
+
+	previous := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		for _, name := range names {
+			if !cmd.Flags().Changed(name) {
+				continue
+			}
+			value, _ := cmd.Flags().GetString(name)
+			id, err := r.resolve(cmd, flags[name], value)
+			if err != nil {
+				return err
+			}
+			if id != value {
+				if err := cmd.Flags().Set(name, id); err != nil {
+					return err
+				}
+			}
+		}
+		return previous(cmd, args)
+	}
+}
+
+// resolve retorna o ID do recurso referenciado por value. IDs são mantidos;
+// nomes são procurados na listagem do serviço e precisam ser únicos.
+func (r *resolver) resolve(cmd *cobra.Command, res resource, value string) (string, error) {
+	manager := i18n.GetInstance()
+
+	name, forced := strings.CutPrefix(value, namePrefix)
+	if !forced && (value == "" || uuidPattern.MatchString(value)) {
+		return value, nil
+	}
+
+	scope := ""
+	if res.parent != "" {
+		scope, _ = cmd.Flags().GetString(res.parent)
+	}
+	key := strings.Join([]string{res.noun, scope, name}, "/")
+	if id, ok := r.cache[key]; ok {
+		return id, nil
+	}
+
+	candidates, err := res.list(cmdutils.CommandContext(cmd), cmd, name)
+	if err != nil {
+		return "", fmt.Errorf(manager.T("cli.resolve.list_failed"), manager.T("cli.resolve.resource."+res.noun), name, err)
+	}
+
+	var matches []candidate
+	for _, item := range candidates {
+		if item.name == name {
+			matches = append(matches, item)
+		}
+	}
+	// Um valor sem o prefixo que não é nome de nenhum recurso pode ser um ID
+	// fora do formato UUID, que segue para a API sem alterações
+	if len(matches) == 0 && !forced {
+		for _, item := range candidates {
+			if item.id == value {
+				return value, nil
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf(manager.T("cli.resolve.not_found"), manager.T("cli.resolve.resource."+res.noun), name)
+	case 1:
+		r.cache[key] = matches[0].id
+		return matches[0].id, nil
+	}
+
+	rows := make([][]string, len(matches))
+	for i, item := range matches {
+		rows[i] = []string{item.name, item.id, item.detail}
+	}
+	raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+	beautiful.NewOutput(raw).PrintTable([]string{
+		manager.T("cli.resolve.column.name"),
+		manager.T("cli.resolve.column.id"),
+		manager.T("cli.resolve.column.detail"),
+	}, rows)
+	return "", fmt.Errorf(manager.T("cli.resolve.ambiguous"), len(matches), manager.T("cli.resolve.resource."+res.noun), name)
+}
@@ -0,0 +1,544 @@
+package resolve
+
+import (
+	"context"
+	"fmt"
+
+	cmdutils "gfcli/cmd_utils"
+
+	blockstorageSdk "github.com/MagaluCloud/mgc-sdk-go/blockstorage"
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	computeSdk "github.com/MagaluCloud/mgc-sdk-go/compute"
+	containerregistrySdk "github.com/MagaluCloud/mgc-sdk-go/containerregistry"
+	dbaasSdk "github.com/MagaluCloud/mgc-sdk-go/dbaas"
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+	lbaasSdk "github.com/MagaluCloud/mgc-sdk-go/lbaas"
+	networkSdk "github.com/MagaluCloud/mgc-sdk-go/network"
+	sshkeysSdk "github.com/MagaluCloud/mgc-sdk-go/sshkeys"
+	"github.com/spf13/cobra"
+)
+
+// candidate é um recurso que pode corresponder ao nome informado, com uma
+// coluna extra que ajuda a diferenciar recursos de mesmo nome
+type candidate struct {
+	id     string
+	name   string
+	detail string
+}
+
+// resource descreve como listar os recursos referenciados por uma flag de
+// ID. O nome é recebido para que serviços com filtro por nome o usem; a
+// comparação exata é sempre feita pelo resolver.
+type resource struct {
+	noun string
+	// parent é a flag que delimita a listagem, como o --cluster-id dos node pools
+	parent string
+	list   func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error)
+}
+
+// rules mapeia o caminho de um produto ou grupo de comandos para as flags de
+// ID que ele usa. A flag --id é sempre do recurso do próprio grupo.
+func rules(sdkCoreConfig sdk.CoreClient) map[string]map[string]resource {
+	vmInstances := computeInstances(sdkCoreConfig)
+	vpcs := networkVPCs(sdkCoreConfig)
+	subnetPools := networkSubnetPools(sdkCoreConfig)
+	securityGroups := networkSecurityGroups(sdkCoreConfig)
+	dbInstances := dbaasInstances(sdkCoreConfig)
+	parameterGroups := dbaasParameterGroups(sdkCoreConfig)
+
+	return map[string]map[string]resource{
+		"virtual-machine":           {"instance.id": vmInstances},
+		"virtual-machine instances": {"id": vmInstances},
+		"virtual-machine snapshots": {"id": computeSnapshots(sdkCoreConfig)},
+
+		"block-storage":           {"volume-id": blockstorageVolumes(sdkCoreConfig), "instance-id": vmInstances},
+		"block-storage volumes":   {"id": blockstorageVolumes(sdkCoreConfig)},
+		"block-storage snapshots": {"id": blockstorageSnapshots(sdkCoreConfig)},
+
+		"network": {
+			"vpc-id":            vpcs,
+			"v-p-c-id":          vpcs,
+			"subnet-pool-id":    subnetPools,
+			"security-group-id": securityGroups,
+			"port-id":           networkPorts(sdkCoreConfig),
+		},
+		"network v-p-cs":          {"id": vpcs},
+		"network subnet-pools":    {"id": subnetPools},
+		"network security-groups": {"id": securityGroups},
+		"network ports":           {"id": networkPorts(sdkCoreConfig)},
+
+		"kubernetes": {
+			"cluster-id":   kubernetesClusters(sdkCoreConfig),
+			"node-pool-id": kubernetesNodePools(sdkCoreConfig),
+		},
+
+		"dbaas": {
+			"instance-id":        dbInstances,
+			"source-id":          dbInstances,
+			"parameter-group-id": parameterGroups,
+			"group-id":           parameterGroups,
+		},
+		"dbaas instances":        {"id": dbInstances, "snapshot-id": dbaasSnapshots(sdkCoreConfig)},
+		"dbaas clusters":         {"id": dbaasClusters(sdkCoreConfig)},
+		"dbaas replicas":         {"id": dbaasReplicas(sdkCoreConfig)},
+		"dbaas parameters-group": {"id": parameterGroups},
+
+		"container-registry": {"registry-id": containerRegistries(sdkCoreConfig)},
+
+		"lbaas": {
+			"load-balancer-id":     lbaasLoadBalancers(sdkCoreConfig),
+			"backend-id":           lbaasBackends(sdkCoreConfig),
+			"listener-id":          lbaasListeners(sdkCoreConfig),
+			"health-check-id":      lbaasHealthChecks(sdkCoreConfig),
+			"t-l-s-certificate-id": lbaasCertificates(sdkCoreConfig),
+			"v-p-c-id":             vpcs,
+			"subnet-pool-id":       subnetPools,
+		},
+
+		"profile keys": {"key-id": sshKeys(sdkCoreConfig)},
+	}
+}
+
+func computeInstances(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "vm_instance",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := computeSdk.New(&sdkCoreConfig).Instances()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]computeSdk.Instance, error) {
+				return service.List(ctx, computeSdk.ListOptions{Limit: limit, Offset: offset, Name: &name})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: cmdutils.Deref(item.Name), detail: item.Status}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func computeSnapshots(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "vm_snapshot",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := computeSdk.New(&sdkCoreConfig).Snapshots()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]computeSdk.Snapshot, error) {
+				return service.List(ctx, computeSdk.ListOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: item.Status}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func blockstorageVolumes(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "volume",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := blockstorageSdk.New(&sdkCoreConfig).Volumes()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]blockstorageSdk.Volume, error) {
+				return service.List(ctx, blockstorageSdk.ListOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: item.Status}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func blockstorageSnapshots(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "volume_snapshot",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := blockstorageSdk.New(&sdkCoreConfig).Snapshots()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]blockstorageSdk.Snapshot, error) {
+				return service.List(ctx, blockstorageSdk.ListOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: string(item.State)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func networkVPCs(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "vpc",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			items, err := networkSdk.New(&sdkCoreConfig).VPCs().List(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: cmdutils.Deref(item.ID), name: cmdutils.Deref(item.Name), detail: item.Status}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func networkSubnetPools(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "subnet_pool",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := networkSdk.New(&sdkCoreConfig).SubnetPools()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]networkSdk.SubnetPoolResponse, error) {
+				return service.List(ctx, networkSdk.ListOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: cmdutils.Deref(item.CIDR)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func networkSecurityGroups(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "security_group",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			items, err := networkSdk.New(&sdkCoreConfig).SecurityGroups().List(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: cmdutils.Deref(item.ID), name: cmdutils.Deref(item.Name), detail: cmdutils.Deref(item.VPCID)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func networkPorts(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "port",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			items, err := networkSdk.New(&sdkCoreConfig).Ports().List(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: cmdutils.Deref(item.ID), name: cmdutils.Deref(item.Name), detail: cmdutils.Deref(item.VPCID)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func kubernetesClusters(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "k8s_cluster",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := kubernetesSdk.New(&sdkCoreConfig).Clusters()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]kubernetesSdk.ClusterList, error) {
+				return service.List(ctx, kubernetesSdk.ListOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: cmdutils.Deref(item.Region)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func kubernetesNodePools(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun:   "node_pool",
+		parent: "cluster-id",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			clusterID, _ := cmd.Flags().GetString("cluster-id")
+			service := kubernetesSdk.New(&sdkCoreConfig).Nodepools()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]kubernetesSdk.NodePool, error) {
+				return service.List(ctx, clusterID, kubernetesSdk.ListOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: item.InstanceTemplate.Flavor.Name}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func dbaasInstances(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "db_instance",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := dbaasSdk.New(&sdkCoreConfig).Instances()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]dbaasSdk.InstanceDetail, error) {
+				return service.List(ctx, dbaasSdk.ListInstanceOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: string(item.Status)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func dbaasSnapshots(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun:   "db_snapshot",
+		parent: "instance-id",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			instanceID, _ := cmd.Flags().GetString("instance-id")
+			service := dbaasSdk.New(&sdkCoreConfig).Instances()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]dbaasSdk.SnapshotDetailResponse, error) {
+				return service.ListSnapshots(ctx, instanceID, dbaasSdk.ListSnapshotOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: string(item.Type)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func dbaasClusters(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "db_cluster",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := dbaasSdk.New(&sdkCoreConfig).Clusters()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]dbaasSdk.ClusterDetailResponse, error) {
+				return service.List(ctx, dbaasSdk.ListClustersOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: string(item.Status)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func dbaasReplicas(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "db_replica",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := dbaasSdk.New(&sdkCoreConfig).Replicas()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]dbaasSdk.ReplicaDetailResponse, error) {
+				return service.List(ctx, dbaasSdk.ListReplicaOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: string(item.Status)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func dbaasParameterGroups(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "parameter_group",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := dbaasSdk.New(&sdkCoreConfig).ParametersGroup()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]dbaasSdk.ParameterGroupDetailResponse, error) {
+				return service.List(ctx, dbaasSdk.ListParameterGroupsOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: string(item.Type)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func containerRegistries(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "registry",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := containerregistrySdk.New(&sdkCoreConfig).Registries()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]containerregistrySdk.RegistryResponse, error) {
+				response, err := service.List(ctx, containerregistrySdk.ListOptions{Limit: limit, Offset: offset})
+				if err != nil {
+					return nil, err
+				}
+				return response.Registries, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: item.CreatedAt}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func lbaasLoadBalancers(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "load_balancer",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := lbaasSdk.New(&sdkCoreConfig).NetworkLoadBalancers()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]lbaasSdk.NetworkLoadBalancerResponse, error) {
+				return service.List(ctx, lbaasSdk.ListNetworkLoadBalancerRequest{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: item.Status}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func lbaasBackends(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun:   "backend",
+		parent: "load-balancer-id",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			loadBalancerID, _ := cmd.Flags().GetString("load-balancer-id")
+			items, err := lbaasSdk.New(&sdkCoreConfig).NetworkBackends().List(ctx, lbaasSdk.ListNetworkBackendRequest{LoadBalancerID: loadBalancerID})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: string(item.BalanceAlgorithm)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func lbaasListeners(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun:   "listener",
+		parent: "load-balancer-id",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			loadBalancerID, _ := cmd.Flags().GetString("load-balancer-id")
+			service := lbaasSdk.New(&sdkCoreConfig).NetworkListeners()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]lbaasSdk.NetworkListenerResponse, error) {
+				return service.List(ctx, lbaasSdk.ListNetworkListenerRequest{LoadBalancerID: loadBalancerID, Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: fmt.Sprintf("%s/%d", item.Protocol, item.Port)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func lbaasHealthChecks(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun:   "health_check",
+		parent: "load-balancer-id",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			loadBalancerID, _ := cmd.Flags().GetString("load-balancer-id")
+			service := lbaasSdk.New(&sdkCoreConfig).NetworkHealthChecks()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]lbaasSdk.NetworkHealthCheckResponse, error) {
+				return service.List(ctx, lbaasSdk.ListNetworkHealthCheckRequest{LoadBalancerID: loadBalancerID, Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: string(item.Protocol)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func lbaasCertificates(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun:   "certificate",
+		parent: "load-balancer-id",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			loadBalancerID, _ := cmd.Flags().GetString("load-balancer-id")
+			service := lbaasSdk.New(&sdkCoreConfig).NetworkCertificates()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]lbaasSdk.NetworkTLSCertificateResponse, error) {
+				return service.List(ctx, lbaasSdk.ListNetworkCertificateRequest{LoadBalancerID: loadBalancerID, Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: cmdutils.Deref(item.ExpirationDate)}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+func sshKeys(sdkCoreConfig sdk.CoreClient) resource {
+	return resource{
+		noun: "ssh_key",
+		list: func(ctx context.Context, cmd *cobra.Command, name string) ([]candidate, error) {
+			service := sshkeysSdk.New(&sdkCoreConfig).Keys()
+			items, err := cmdutils.Paged(func(limit, offset *int) ([]sshkeysSdk.SSHKey, error) {
+				return service.List(ctx, sshkeysSdk.ListOptions{Limit: limit, Offset: offset})
+			})
+			if err != nil {
+				return nil, err
+			}
+			candidates := make([]candidate, len(items))
+			for i, item := range items {
+				candidates[i] = candidate{id: item.ID, name: item.Name, detail: item.KeyType}
+			}
+			return candidates, nil
+		},
+	}
+}
@@ -2,6 +2,17 @@ package static
 
 import (
 	"gfcli/cmd/static/config"
+	"gfcli/cmd/static/containerregistry"
+	"gfcli/cmd/static/crash"
+	"gfcli/cmd/static/dbaas"
+	"gfcli/cmd/static/explore"
+	"gfcli/cmd/static/kubeconfig"
+	"gfcli/cmd/static/kubernetes"
+	"gfcli/cmd/static/resolve"
+	"gfcli/cmd/static/sshkeys"
+	"gfcli/cmd/static/ui"
+	"gfcli/cmd/static/vm"
+	"gfcli/cmd/static/wizard"
 
 	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
 	"github.com/spf13/cobra"
@@ -10,5 +21,29 @@ import (
 func RootStatic(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
 
 	config.ConfigCmd(parent, sdkCoreConfig)
+	crash.CrashCmd(parent)
+	explore.ExploreCmd(parent)
+	ui.UICmd(parent, sdkCoreConfig)
+
+}
+
+// RootStaticExtensions estende os comandos gerados e por isso deve ser
+// chamado depois de gen.RootGen
+func RootStaticExtensions(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+
+	wizard.WizardCmd(parent, sdkCoreConfig)
+	kubeconfig.KubeconfigCmd(parent, sdkCoreConfig)
+	kubernetes.UpgradeCmd(parent, sdkCoreConfig)
+	kubernetes.ApplyCmd(parent, sdkCoreConfig)
+	vm.SSHCmd(parent, sdkCoreConfig)
+	vm.WindowsPasswordCmd(parent, sdkCoreConfig)
+	vm.UserDataCmd(parent)
+	vm.InitLogCmd(parent, sdkCoreConfig)
+	sshkeys.SSHKeysCmd(parent, sdkCoreConfig)
+	containerregistry.ContainerRegistryCmd(parent, sdkCoreConfig)
+	dbaas.PasswordCmd(parent)
+	dbaas.ParametersGroupCmd(parent, sdkCoreConfig)
+	// A resolução de nomes envolve os RunE das extensões acima, então vem por último
+	resolve.ResolveCmd(parent, sdkCoreConfig)
 
 }
@@ -0,0 +1,75 @@
+package sshkeys
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// Mensagens do protocolo do ssh-agent usadas para listar as chaves
+const (
+	agentRequestIdentities = 11
+	agentIdentitiesAnswer  = 12
+)
+
+// agentKeys lista as chaves carregadas no ssh-agent de SSH_AUTH_SOCK. Sem
+// agente, o resultado é vazio.
+func agentKeys() ([]publicKey, error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, nil
+	}
+
+	conn, err := net.DialTimeout("unix", socket, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Write([]byte{0, 0, 0, 1, agentRequestIdentities}); err != nil {
+		return nil, err
+	}
+
+	var header [4]byte
+	if _, err := io.ReadFull(conn, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size < 5 || size > 256*1024 {
+		return nil, errors.New("invalid ssh-agent response")
+	}
+	message := make([]byte, size)
+	if _, err := io.ReadFull(conn, message); err != nil {
+		return nil, err
+	}
+	if message[0] != agentIdentitiesAnswer {
+		return nil, errors.New("invalid ssh-agent response")
+	}
+
+	count := binary.BigEndian.Uint32(message[1:])
+	data := message[5:]
+	// Cada chave ocupa ao menos 8 bytes, então uma contagem maior é inválida
+	// e não pode definir o tamanho da alocação
+	if uint64(count)*8 > uint64(len(data)) {
+		return nil, errors.New("invalid ssh-agent response")
+	}
+	keys := make([]publicKey, 0, count)
+	for range count {
+		blob, rest, ok := readString(data)
+		if !ok {
+			return nil, errors.New("invalid ssh-agent response")
+		}
+		comment, rest, ok := readString(rest)
+		if !ok {
+			return nil, errors.New("invalid ssh-agent response")
+		}
+		keyType, _, _ := readString(blob)
+		keys = append(keys, publicKey{keyType: string(keyType), blob: blob, comment: string(comment)})
+		data = rest
+	}
+	return keys, nil
+}
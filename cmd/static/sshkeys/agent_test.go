@@ -0,0 +1,118 @@
+package sshkeys
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeAgent responde ao pedido de identidades com reply e retorna o socket
+func fakeAgent(t *testing.T, reply []byte) string {
+	t.Helper()
+
+	// O caminho de um socket unix tem limite de tamanho, curto demais para o t.TempDir
+	dir, err := os.MkdirTemp("", "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		request := make([]byte, 5)
+		if _, err := io.ReadFull(conn, request); err != nil || request[4] != agentRequestIdentities {
+			return
+		}
+		conn.Write(reply)
+	}()
+	return socket
+}
+
+// agentMessage monta uma mensagem do ssh-agent com o tamanho no início
+func agentMessage(body ...[]byte) []byte {
+	var w wire
+	for _, part := range body {
+		w.Write(part)
+	}
+	return append(binary.BigEndian.AppendUint32(nil, uint32(w.Len())), w.Bytes()...)
+}
+
+// identities monta o corpo de uma resposta com count chaves, seguido de keys
+func identities(count uint32, keys ...publicKey) []byte {
+	var w wire
+	w.WriteByte(agentIdentitiesAnswer)
+	w.uint32(count)
+	for _, key := range keys {
+		w.string(key.blob)
+		w.string([]byte(key.comment))
+	}
+	return w.Bytes()
+}
+
+func TestAgentKeys(t *testing.T) {
+	ed, _ := parseAuthorizedKey(ed25519Public)
+	ec, _ := parseAuthorizedKey(ecdsaPublic)
+
+	t.Setenv("SSH_AUTH_SOCK", fakeAgent(t, agentMessage(identities(2, ed, ec))))
+	keys, err := agentKeys()
+	if err != nil {
+		t.Fatalf("agentKeys: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(keys))
+	}
+	for i, want := range []publicKey{ed, ec} {
+		if keys[i].keyType != want.keyType || keys[i].comment != want.comment || keys[i].fingerprint() != want.fingerprint() {
+			t.Errorf("key %d = %s, want %s", i, keys[i].authorizedKey(), want.authorizedKey())
+		}
+	}
+}
+
+func TestAgentKeysWithoutAgent(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	keys, err := agentKeys()
+	if err != nil || keys != nil {
+		t.Errorf("agentKeys() = %v, %v; want no keys and no error", keys, err)
+	}
+}
+
+func TestAgentKeysMalformedReply(t *testing.T) {
+	ed, _ := parseAuthorizedKey(ed25519Public)
+	truncated := identities(1, ed)
+
+	tests := []struct {
+		name  string
+		reply []byte
+	}{
+		{"failure message", agentMessage([]byte{5})},
+		{"short message", agentMessage([]byte{agentIdentitiesAnswer, 0, 0})},
+		{"oversized message", binary.BigEndian.AppendUint32(nil, 1<<20)},
+		{"more keys than sent", agentMessage(identities(2, ed))},
+		{"huge key count", agentMessage(identities(1<<32-1, ed))},
+		{"truncated blob", agentMessage(truncated[:len(truncated)-20])},
+		{"missing comment", agentMessage(identities(1, ed)[:5+4+len(ed.blob)])},
+		{"connection closed", agentMessage(identities(1, ed))[:10]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SSH_AUTH_SOCK", fakeAgent(t, tt.reply))
+			if keys, err := agentKeys(); err == nil {
+				t.Errorf("agentKeys accepted a malformed reply and returned %d keys", len(keys))
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package sshkeys
+
+import (
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+const keyFileFlag = "key-file"
+
+// keyFile adiciona ao 'keys create' a flag --key-file, que lê a chave pública
+// de um arquivo .pub em vez do texto passado em --key
+func keyFile(keys *cobra.Command) {
+	manager := i18n.GetInstance()
+
+	create := cmdutils.Subcommand(keys, "create")
+	if create == nil {
+		return
+	}
+
+	create.Flags().String(keyFileFlag, "", manager.T("cli.sshkeys.flag.key_file"))
+	// O --key gerado deixa de ser obrigatório: basta uma das duas flags
+	create.Flags().SetAnnotation("key", cobra.BashCompOneRequiredFlag, []string{"false"})
+	create.MarkFlagsOneRequired("key", keyFileFlag)
+	create.MarkFlagsMutuallyExclusive("key", keyFileFlag)
+
+	previous := create.RunE
+	create.RunE = func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString(keyFileFlag)
+		if path != "" {
+			key, err := loadPublicKeyFile(cmdutils.ExpandHome(path))
+			if err != nil {
+				return err
+			}
+			if err := cmd.Flags().Set("key", key.authorizedKey()); err != nil {
+				return err
+			}
+		}
+		return previous(cmd, args)
+	}
+}
+
+// loadPublicKeyFile lê um arquivo .pub, recusando chaves privadas passadas por engano
+func loadPublicKeyFile(path string) (publicKey, error) {
+	manager := i18n.GetInstance()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return publicKey{}, err
+	}
+	if block, _ := pem.Decode(data); block != nil && strings.Contains(block.Type, "PRIVATE KEY") {
+		return publicKey{}, fmt.Errorf(manager.T("cli.sshkeys.private_key_file"), path, path+".pub")
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, err := parseAuthorizedKey(line)
+		if err != nil {
+			return publicKey{}, fmt.Errorf(manager.T("cli.sshkeys.invalid_public_key"), path)
+		}
+		return key, nil
+	}
+	return publicKey{}, fmt.Errorf(manager.T("cli.sshkeys.invalid_public_key"), path)
+}
@@ -0,0 +1,72 @@
+package sshkeys
+
+import (
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+
+	sshkeysSdk "github.com/MagaluCloud/mgc-sdk-go/sshkeys"
+	"github.com/spf13/cobra"
+)
+
+// keyWithFingerprint é a chave retornada pela API acrescida da impressão digital
+type keyWithFingerprint struct {
+	sshkeysSdk.SSHKey
+	Fingerprint string `json:"fingerprint"`
+}
+
+func withFingerprint(key sshkeysSdk.SSHKey) keyWithFingerprint {
+	result := keyWithFingerprint{SSHKey: key}
+	if public, err := parseAuthorizedKey(key.Key); err == nil {
+		result.Fingerprint = public.fingerprint()
+	}
+	return result
+}
+
+// fingerprints troca a execução do 'keys list' e do 'keys get' gerados para
+// incluir a impressão digital SHA256 de cada chave, como a do ssh-keygen -l
+func fingerprints(keys *cobra.Command, service sshkeysSdk.KeyService) {
+	if list := cmdutils.Subcommand(keys, "list"); list != nil {
+		list.RunE = func(cmd *cobra.Command, args []string) error {
+			var opts sshkeysSdk.ListOptions
+			if cmd.Flags().Changed("limit") {
+				limit, _ := cmd.Flags().GetInt("limit")
+				opts.Limit = &limit
+			}
+			if cmd.Flags().Changed("offset") {
+				offset, _ := cmd.Flags().GetInt("offset")
+				opts.Offset = &offset
+			}
+			if cmd.Flags().Changed("sort") {
+				sort, _ := cmd.Flags().GetString("sort")
+				opts.Sort = &sort
+			}
+
+			sshkeys, err := service.List(cmdutils.CommandContext(cmd), opts)
+			if err != nil {
+				return err
+			}
+			result := make([]keyWithFingerprint, len(sshkeys))
+			for i, key := range sshkeys {
+				result[i] = withFingerprint(key)
+			}
+
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			beautiful.NewOutput(raw).PrintData(result)
+			return nil
+		}
+	}
+
+	if get := cmdutils.Subcommand(keys, "get"); get != nil {
+		get.RunE = func(cmd *cobra.Command, args []string) error {
+			keyID, _ := cmd.Flags().GetString("key-id")
+			sshkey, err := service.Get(cmdutils.CommandContext(cmd), keyID)
+			if err != nil {
+				return err
+			}
+
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			beautiful.NewOutput(raw).PrintData(withFingerprint(*sshkey))
+			return nil
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package sshkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sshkeysSdk "github.com/MagaluCloud/mgc-sdk-go/sshkeys"
+	"github.com/spf13/cobra"
+)
+
+// generateCmd cria o 'keys generate': gera o par de chaves localmente, grava
+// os arquivos e envia só a metade pública para a API
+func generateCmd(service sshkeysSdk.KeyService) *cobra.Command {
+	manager := i18n.GetInstance()
+
+	var name, keyType, output, comment string
+	var bits int
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: manager.T("cli.sshkeys.generate.short"),
+		Long:  manager.T("cli.sshkeys.generate.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				home, err := os.UserHomeDir()
+				if err != nil {
+					return err
+				}
+				output = filepath.Join(home, ".ssh", "mgc_"+name)
+			}
+			output = cmdutils.ExpandHome(output)
+			if comment == "" {
+				comment = name
+			}
+
+			for _, path := range []string{output, output + ".pub"} {
+				if _, err := os.Stat(path); err == nil {
+					return fmt.Errorf(manager.T("cli.sshkeys.generate.exists"), path)
+				}
+			}
+
+			private, public, err := generateKey(keyType, bits, comment)
+			if err != nil {
+				return err
+			}
+
+			// Os arquivos são gravados antes do envio, para que nunca exista
+			// na cloud uma chave cuja metade privada se perdeu
+			if err := os.MkdirAll(filepath.Dir(output), 0700); err != nil {
+				return err
+			}
+			if err := os.WriteFile(output, private, 0600); err != nil {
+				return err
+			}
+			if err := os.WriteFile(output+".pub", []byte(public.authorizedKey()+"\n"), 0644); err != nil {
+				return err
+			}
+
+			created, err := service.Create(cmdutils.CommandContext(cmd), sshkeysSdk.CreateSSHKeyRequest{Name: name, Key: public.authorizedKey()})
+			if err != nil {
+				return fmt.Errorf(manager.T("cli.sshkeys.generate.upload_failed"), output, err)
+			}
+
+			// Liga a chave ao arquivo local para o 'virtual-machine instances ssh'
+			config, err := cmdutils.LoadUserConfig()
+			if err == nil {
+				config[keyConfigPrefix+name] = output
+				err = config.Save()
+			}
+
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			out := beautiful.NewOutput(raw)
+			if err != nil {
+				out.PrintWarning(manager.T("cli.sshkeys.generate.config_failed", err))
+			}
+			out.PrintData(withFingerprint(*created))
+			out.PrintSuccess(manager.T("cli.sshkeys.generate.success", name, output, output+".pub"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", manager.T("cli.sshkeys.generate.flag.name"))
+	cmd.Flags().StringVarP(&keyType, "type", "t", "ed25519", manager.T("cli.sshkeys.generate.flag.type"))
+	cmd.Flags().IntVarP(&bits, "bits", "b", 4096, manager.T("cli.sshkeys.generate.flag.bits"))
+	cmd.Flags().StringVarP(&output, "output", "o", "", manager.T("cli.sshkeys.generate.flag.output"))
+	cmd.Flags().StringVarP(&comment, "comment", "C", "", manager.T("cli.sshkeys.generate.flag.comment"))
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// generateKey gera a chave privada no formato lido pelo ssh e a chave pública correspondente
+func generateKey(keyType string, bits int, comment string) ([]byte, publicKey, error) {
+	manager := i18n.GetInstance()
+
+	switch keyType {
+	case "ed25519":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, publicKey{}, err
+		}
+		public, err := publicKeyOf(key)
+		if err != nil {
+			return nil, publicKey{}, err
+		}
+		private, err := marshalOpenSSH(key, comment)
+		public.comment = comment
+		return private, public, err
+	case "rsa":
+		if bits < 2048 {
+			return nil, publicKey{}, fmt.Errorf(manager.T("cli.sshkeys.generate.bits_too_small"), bits)
+		}
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return nil, publicKey{}, err
+		}
+		public, err := publicKeyOf(key)
+		public.comment = comment
+		return marshalRSA(key), public, err
+	}
+	return nil, publicKey{}, fmt.Errorf(manager.T("cli.sshkeys.generate.invalid_type"), keyType)
+}
@@ -0,0 +1,133 @@
+package sshkeys
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sshkeysSdk "github.com/MagaluCloud/mgc-sdk-go/sshkeys"
+	"github.com/spf13/cobra"
+)
+
+// errEncrypted interrompe a leitura de chaves privadas cifradas: o 'match' não pede senhas
+var errEncrypted = errors.New("encrypted private key")
+
+// ignoredFiles são arquivos do ~/.ssh que não são chaves
+var ignoredFiles = []string{"known_hosts", "authorized_keys", "config", "environment"}
+
+// matchCmd cria o 'keys match', que indica quais chaves cadastradas têm a
+// chave privada correspondente no diretório local ou no ssh-agent
+func matchCmd(service sshkeysSdk.KeyService) *cobra.Command {
+	manager := i18n.GetInstance()
+
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "match",
+		Short: manager.T("cli.sshkeys.match.short"),
+		Long:  manager.T("cli.sshkeys.match.long"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			output := beautiful.NewOutput(raw)
+
+			if dir == "" {
+				home, err := os.UserHomeDir()
+				if err != nil {
+					return err
+				}
+				dir = filepath.Join(home, ".ssh")
+			}
+
+			uploaded, err := listAll(cmdutils.CommandContext(cmd), service)
+			if err != nil {
+				return err
+			}
+
+			local := localKeys(cmdutils.ExpandHome(dir))
+			agent, err := agentKeys()
+			if err != nil {
+				output.PrintWarning(manager.T("cli.sshkeys.match.agent_failed", err))
+			}
+			for _, key := range agent {
+				local[key.fingerprint()] = append(local[key.fingerprint()], manager.T("cli.sshkeys.match.agent", key.comment))
+			}
+
+			rows := make([][]string, len(uploaded))
+			matched := 0
+			for i, key := range uploaded {
+				item := withFingerprint(key)
+				sources := local[item.Fingerprint]
+				found := "-"
+				if item.Fingerprint != "" && len(sources) > 0 {
+					found = strings.Join(sources, ", ")
+					matched++
+				}
+				rows[i] = []string{item.Name, item.KeyType, item.Fingerprint, found}
+			}
+
+			output.PrintTable([]string{
+				manager.T("cli.sshkeys.column.name"),
+				manager.T("cli.sshkeys.column.type"),
+				manager.T("cli.sshkeys.column.fingerprint"),
+				manager.T("cli.sshkeys.column.local"),
+			}, rows)
+			output.PrintInfo(manager.TN("cli.sshkeys.match.summary", matched, i18n.Args{"total": len(uploaded)}))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", manager.T("cli.sshkeys.match.flag.dir"))
+
+	return cmd
+}
+
+// localKeys mapeia a impressão digital das chaves privadas do diretório para
+// os seus arquivos. Um .pub só conta quando a chave privada está ao lado dele.
+func localKeys(dir string) map[string][]string {
+	keys := make(map[string][]string)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return keys
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || ignored(name) {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		private := path
+		if strings.HasSuffix(name, ".pub") {
+			private = strings.TrimSuffix(path, ".pub")
+			if _, err := os.Stat(private); err != nil {
+				continue
+			}
+		}
+
+		key, err := readPublicKey(path)
+		if err != nil {
+			continue
+		}
+		fingerprint := key.fingerprint()
+		if !slices.Contains(keys[fingerprint], private) {
+			keys[fingerprint] = append(keys[fingerprint], private)
+		}
+	}
+	return keys
+}
+
+func ignored(name string) bool {
+	for _, prefix := range ignoredFiles {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package sshkeys
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// opensshMagic abre o bloco binário das chaves privadas no formato do OpenSSH
+const opensshMagic = "openssh-key-v1\x00"
+
+// publicKey é uma chave pública no formato de fio do SSH (RFC 4253)
+type publicKey struct {
+	keyType string
+	blob    []byte
+	comment string
+}
+
+// fingerprint retorna a impressão digital SHA256 no mesmo formato do ssh-keygen -l
+func (k publicKey) fingerprint() string {
+	sum := sha256.Sum256(k.blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// authorizedKey retorna a linha no formato do authorized_keys
+func (k publicKey) authorizedKey() string {
+	line := k.keyType + " " + base64.StdEncoding.EncodeToString(k.blob)
+	if k.comment != "" {
+		line += " " + k.comment
+	}
+	return line
+}
+
+// parseAuthorizedKey lê uma linha "tipo base64 [comentário]", como a de um arquivo .pub
+func parseAuthorizedKey(line string) (publicKey, error) {
+	fields := strings.Fields(strings.TrimSpace(line))
+	if len(fields) < 2 {
+		return publicKey{}, errors.New("invalid public key")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return publicKey{}, err
+	}
+	keyType, _, ok := readString(blob)
+	if !ok || string(keyType) != fields[0] {
+		return publicKey{}, errors.New("invalid public key")
+	}
+	return publicKey{keyType: fields[0], blob: blob, comment: strings.Join(fields[2:], " ")}, nil
+}
+
+// publicKeyOf gera a chave pública de uma chave privada RSA, ECDSA ou Ed25519
+func publicKeyOf(key any) (publicKey, error) {
+	var w wire
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		w.string([]byte("ssh-rsa"))
+		w.mpint(big.NewInt(int64(k.E)))
+		w.mpint(k.N)
+		return publicKey{keyType: "ssh-rsa", blob: w.Bytes()}, nil
+	case ed25519.PrivateKey:
+		w.string([]byte("ssh-ed25519"))
+		w.string(k.Public().(ed25519.PublicKey))
+		return publicKey{keyType: "ssh-ed25519", blob: w.Bytes()}, nil
+	case *ecdsa.PrivateKey:
+		curve := map[elliptic.Curve]string{elliptic.P256(): "nistp256", elliptic.P384(): "nistp384", elliptic.P521(): "nistp521"}[k.Curve]
+		if curve == "" {
+			return publicKey{}, errors.New("unsupported curve")
+		}
+		public, err := k.PublicKey.ECDH()
+		if err != nil {
+			return publicKey{}, err
+		}
+		point := public.Bytes()
+		keyType := "ecdsa-sha2-" + curve
+		w.string([]byte(keyType))
+		w.string([]byte(curve))
+		w.string(point)
+		return publicKey{keyType: keyType, blob: w.Bytes()}, nil
+	}
+	return publicKey{}, fmt.Errorf("unsupported key type %T", key)
+}
+
+// opensshPublicKey lê a chave pública guardada sem cifra no início de uma
+// chave privada do OpenSSH, o que dispensa a senha da chave
+func opensshPublicKey(block *pem.Block) (publicKey, error) {
+	invalid := errors.New("invalid OpenSSH private key")
+
+	data, ok := bytes.CutPrefix(block.Bytes, []byte(opensshMagic))
+	if !ok {
+		return publicKey{}, invalid
+	}
+	// ciphername, kdfname e kdfoptions
+	for range 3 {
+		if _, data, ok = readString(data); !ok {
+			return publicKey{}, invalid
+		}
+	}
+	if len(data) < 4 || binary.BigEndian.Uint32(data) < 1 {
+		return publicKey{}, invalid
+	}
+	blob, _, ok := readString(data[4:])
+	if !ok {
+		return publicKey{}, invalid
+	}
+	keyType, _, ok := readString(blob)
+	if !ok {
+		return publicKey{}, invalid
+	}
+	return publicKey{keyType: string(keyType), blob: blob}, nil
+}
+
+// marshalOpenSSH grava uma chave Ed25519 no formato do OpenSSH, sem cifra
+func marshalOpenSSH(key ed25519.PrivateKey, comment string) ([]byte, error) {
+	public, err := publicKeyOf(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var check [4]byte
+	if _, err := rand.Read(check[:]); err != nil {
+		return nil, err
+	}
+	var private wire
+	private.Write(check[:])
+	private.Write(check[:])
+	private.string([]byte("ssh-ed25519"))
+	private.string(key.Public().(ed25519.PublicKey))
+	private.string(key)
+	private.string([]byte(comment))
+	for i := byte(1); private.Len()%8 != 0; i++ {
+		private.WriteByte(i)
+	}
+
+	var w wire
+	w.WriteString(opensshMagic)
+	w.string([]byte("none"))
+	w.string([]byte("none"))
+	w.string(nil)
+	w.uint32(1)
+	w.string(public.blob)
+	w.string(private.Bytes())
+	return pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: w.Bytes()}), nil
+}
+
+// marshalRSA grava uma chave RSA em PEM PKCS#1, formato lido pelo ssh e pelo
+// 'get-first-windows-password --private-key'
+func marshalRSA(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// wire escreve os tipos do protocolo SSH
+type wire struct {
+	bytes.Buffer
+}
+
+func (w *wire) uint32(n uint32) {
+	w.Write(binary.BigEndian.AppendUint32(nil, n))
+}
+
+func (w *wire) string(data []byte) {
+	w.uint32(uint32(len(data)))
+	w.Write(data)
+}
+
+func (w *wire) mpint(n *big.Int) {
+	data := n.Bytes()
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		data = append([]byte{0}, data...)
+	}
+	w.string(data)
+}
+
+// readString lê um string do protocolo SSH e retorna o restante dos dados
+func readString(data []byte) (value, rest []byte, ok bool) {
+	if len(data) < 4 {
+		return nil, nil, false
+	}
+	size := binary.BigEndian.Uint32(data)
+	if uint64(size) > uint64(len(data)-4) {
+		return nil, nil, false
+	}
+	return data[4 : 4+size], data[4+size:], true
+}
@@ -0,0 +1,62 @@
+package sshkeys
+
+import (
+	"context"
+	"encoding/pem"
+	"os"
+
+	cmdutils "gfcli/cmd_utils"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	sshkeysSdk "github.com/MagaluCloud/mgc-sdk-go/sshkeys"
+	"github.com/spf13/cobra"
+)
+
+// keyConfigPrefix liga o nome de uma chave da cloud ao arquivo local da chave
+// privada, como em 'cli config set ssh.key.<nome> <caminho>'. É a mesma
+// configuração lida pelo 'virtual-machine instances ssh'.
+const keyConfigPrefix = "ssh.key."
+
+// SSHKeysCmd estende o grupo gerado 'profile keys' com a importação de
+// arquivos, a geração local de chaves, as impressões digitais e o 'match'.
+func SSHKeysCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	keys, _, err := parent.Find([]string{"profile", "keys"})
+	if err != nil || keys == nil || keys.Name() != "keys" {
+		return
+	}
+	keys.Aliases = append(keys.Aliases, "ssh-keys")
+
+	service := sshkeysSdk.New(&sdkCoreConfig).Keys()
+	keyFile(keys)
+	fingerprints(keys, service)
+	keys.AddCommand(generateCmd(service))
+	keys.AddCommand(matchCmd(service))
+}
+
+// listAll percorre todas as páginas das chaves cadastradas
+func listAll(ctx context.Context, service sshkeysSdk.KeyService) ([]sshkeysSdk.SSHKey, error) {
+	return cmdutils.Paged(func(limit, offset *int) ([]sshkeysSdk.SSHKey, error) {
+		return service.List(ctx, sshkeysSdk.ListOptions{Limit: limit, Offset: offset})
+	})
+}
+
+// readPublicKey lê a chave pública de um arquivo .pub ou, para chaves do
+// OpenSSH e chaves PEM sem cifra, da própria chave privada
+func readPublicKey(path string) (publicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return publicKey{}, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return parseAuthorizedKey(string(data))
+	}
+	if block.Type == "OPENSSH PRIVATE KEY" {
+		return opensshPublicKey(block)
+	}
+	private, err := cmdutils.ParsePrivateKey(data, func() ([]byte, error) { return nil, errEncrypted })
+	if err != nil {
+		return publicKey{}, err
+	}
+	return publicKeyOf(private)
+}
@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	blockstorageSdk "github.com/MagaluCloud/mgc-sdk-go/blockstorage"
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	computeSdk "github.com/MagaluCloud/mgc-sdk-go/compute"
+	dbaasSdk "github.com/MagaluCloud/mgc-sdk-go/dbaas"
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+)
+
+// product agrupa os tipos de recurso navegáveis de um produto
+type product struct {
+	name      string
+	resources []resource
+}
+
+// resource descreve como listar, detalhar e agir sobre um tipo de recurso
+type resource struct {
+	name    string
+	headers []string
+	list    func(ctx context.Context) ([]row, error)
+	get     func(ctx context.Context, id string) (any, error)
+	actions []action
+}
+
+// row é uma linha da listagem de recursos
+type row struct {
+	id      string
+	name    string
+	columns []string
+}
+
+// action é uma operação executada por atalho sobre o recurso selecionado.
+// Quando prompt é informado, o valor digitado é repassado para run.
+type action struct {
+	key     string
+	label   string
+	confirm bool
+	prompt  string
+	run     func(ctx context.Context, id, value string) error
+}
+
+// catalog monta os produtos disponíveis no cli ui
+func catalog(sdkCoreConfig sdk.CoreClient) []product {
+	manager := i18n.GetInstance()
+
+	compute := computeSdk.New(&sdkCoreConfig)
+	blockstorage := blockstorageSdk.New(&sdkCoreConfig)
+	dbaas := dbaasSdk.New(&sdkCoreConfig)
+	kubernetes := kubernetesSdk.New(&sdkCoreConfig)
+
+	return []product{
+		{
+			name:      manager.T("cli.ui.product.virtual_machine"),
+			resources: []resource{vmInstances(compute.Instances())},
+		},
+		{
+			name:      manager.T("cli.ui.product.block_storage"),
+			resources: []resource{volumes(blockstorage.Volumes())},
+		},
+		{
+			name: manager.T("cli.ui.product.dbaas"),
+			resources: []resource{
+				dbaasInstances(dbaas.Instances()),
+				dbaasClusters(dbaas.Clusters()),
+			},
+		},
+		{
+			name:      manager.T("cli.ui.product.kubernetes"),
+			resources: []resource{kubernetesClusters(kubernetes.Clusters())},
+		},
+	}
+}
+
+func vmInstances(service computeSdk.InstanceService) resource {
+	manager := i18n.GetInstance()
+	return resource{
+		name:    manager.T("cli.ui.resource.instances"),
+		headers: headers("name", "id", "status", "state", "machine_type", "availability_zone"),
+		list: func(ctx context.Context) ([]row, error) {
+			instances, err := service.List(ctx, computeSdk.ListOptions{
+				Expand: []string{computeSdk.InstanceMachineTypeExpand},
+			})
+			if err != nil {
+				return nil, err
+			}
+			rows := make([]row, 0, len(instances))
+			for _, instance := range instances {
+				machineType := ""
+				if instance.MachineType != nil {
+					machineType = cmdutils.Deref(instance.MachineType.Name)
+				}
+				name := cmdutils.Deref(instance.Name)
+				rows = append(rows, row{
+					id:      instance.ID,
+					name:    name,
+					columns: []string{name, instance.ID, instance.Status, instance.State, machineType, cmdutils.Deref(instance.AvailabilityZone)},
+				})
+			}
+			return rows, nil
+		},
+		get: func(ctx context.Context, id string) (any, error) {
+			return service.Get(ctx, id, []string{
+				computeSdk.InstanceImageExpand,
+				computeSdk.InstanceMachineTypeExpand,
+				computeSdk.InstanceNetworkExpand,
+			})
+		},
+		actions: []action{
+			startAction(func(ctx context.Context, id string) error { return service.Start(ctx, id) }),
+			stopAction(func(ctx context.Context, id string) error { return service.Stop(ctx, id) }),
+			deleteAction(func(ctx context.Context, id string) error { return service.Delete(ctx, id, false) }),
+			renameAction(service.Rename),
+		},
+	}
+}
+
+func volumes(service blockstorageSdk.VolumeService) resource {
+	manager := i18n.GetInstance()
+	return resource{
+		name:    manager.T("cli.ui.resource.volumes"),
+		headers: headers("name", "id", "size", "status", "state", "attached_to"),
+		list: func(ctx context.Context) ([]row, error) {
+			volumes, err := service.List(ctx, blockstorageSdk.ListOptions{
+				Expand: []string{blockstorageSdk.VolumeAttachExpand},
+			})
+			if err != nil {
+				return nil, err
+			}
+			rows := make([]row, 0, len(volumes))
+			for _, volume := range volumes {
+				attachedTo := ""
+				if volume.Attachment != nil {
+					attachedTo = cmdutils.Deref(volume.Attachment.Instance.ID)
+				}
+				rows = append(rows, row{
+					id:      volume.ID,
+					name:    volume.Name,
+					columns: []string{volume.Name, volume.ID, fmt.Sprintf("%d GB", volume.Size), volume.Status, volume.State, attachedTo},
+				})
+			}
+			return rows, nil
+		},
+		get: func(ctx context.Context, id string) (any, error) {
+			return service.Get(ctx, id, []string{blockstorageSdk.VolumeTypeExpand, blockstorageSdk.VolumeAttachExpand})
+		},
+		actions: []action{
+			deleteAction(service.Delete),
+			renameAction(service.Rename),
+		},
+	}
+}
+
+func dbaasInstances(service dbaasSdk.InstanceService) resource {
+	manager := i18n.GetInstance()
+	return resource{
+		name:    manager.T("cli.ui.resource.instances"),
+		headers: headers("name", "id", "status", "engine", "availability_zone"),
+		list: func(ctx context.Context) ([]row, error) {
+			instances, err := service.List(ctx, dbaasSdk.ListInstanceOptions{})
+			if err != nil {
+				return nil, err
+			}
+			rows := make([]row, 0, len(instances))
+			for _, instance := range instances {
+				rows = append(rows, row{
+					id:      instance.ID,
+					name:    instance.Name,
+					columns: []string{instance.Name, instance.ID, string(instance.Status), instance.EngineID, instance.AvailabilityZone},
+				})
+			}
+			return rows, nil
+		},
+		get: func(ctx context.Context, id string) (any, error) {
+			return service.Get(ctx, id, dbaasSdk.GetInstanceOptions{})
+		},
+		actions: []action{
+			startAction(func(ctx context.Context, id string) error {
+				_, err := service.Start(ctx, id)
+				return err
+			}),
+			stopAction(func(ctx context.Context, id string) error {
+				_, err := service.Stop(ctx, id)
+				return err
+			}),
+			deleteAction(service.Delete),
+		},
+	}
+}
+
+func dbaasClusters(service dbaasSdk.ClusterService) resource {
+	manager := i18n.GetInstance()
+	return resource{
+		name:    manager.T("cli.ui.resource.clusters"),
+		headers: headers("name", "id", "status", "engine"),
+		list: func(ctx context.Context) ([]row, error) {
+			clusters, err := service.List(ctx, dbaasSdk.ListClustersOptions{})
+			if err != nil {
+				return nil, err
+			}
+			rows := make([]row, 0, len(clusters))
+			for _, cluster := range clusters {
+				rows = append(rows, row{
+					id:      cluster.ID,
+					name:    cluster.Name,
+					columns: []string{cluster.Name, cluster.ID, string(cluster.Status), cluster.EngineID},
+				})
+			}
+			return rows, nil
+		},
+		get: func(ctx context.Context, id string) (any, error) {
+			return service.Get(ctx, id)
+		},
+		actions: []action{
+			startAction(func(ctx context.Context, id string) error {
+				_, err := service.Start(ctx, id)
+				return err
+			}),
+			stopAction(func(ctx context.Context, id string) error {
+				_, err := service.Stop(ctx, id)
+				return err
+			}),
+			deleteAction(service.Delete),
+		},
+	}
+}
+
+func kubernetesClusters(service kubernetesSdk.ClusterService) resource {
+	manager := i18n.GetInstance()
+	return resource{
+		name:    manager.T("cli.ui.resource.clusters"),
+		headers: headers("name", "id", "status", "version"),
+		list: func(ctx context.Context) ([]row, error) {
+			clusters, err := service.List(ctx, kubernetesSdk.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			rows := make([]row, 0, len(clusters))
+			for _, cluster := range clusters {
+				status := ""
+				if cluster.Status != nil {
+					status = cluster.Status.State
+				}
+				rows = append(rows, row{
+					id:      cluster.ID,
+					name:    cluster.Name,
+					columns: []string{cluster.Name, cluster.ID, status, cmdutils.Deref(cluster.Version)},
+				})
+			}
+			return rows, nil
+		},
+		get: func(ctx context.Context, id string) (any, error) {
+			return service.Get(ctx, id)
+		},
+		actions: []action{
+			deleteAction(service.Delete),
+		},
+	}
+}
+
+func startAction(run func(ctx context.Context, id string) error) action {
+	return action{
+		key:     "s",
+		label:   i18n.GetInstance().T("cli.ui.action.start"),
+		confirm: true,
+		run:     func(ctx context.Context, id, _ string) error { return run(ctx, id) },
+	}
+}
+
+func stopAction(run func(ctx context.Context, id string) error) action {
+	return action{
+		key:     "t",
+		label:   i18n.GetInstance().T("cli.ui.action.stop"),
+		confirm: true,
+		run:     func(ctx context.Context, id, _ string) error { return run(ctx, id) },
+	}
+}
+
+func deleteAction(run func(ctx context.Context, id string) error) action {
+	return action{
+		key:     "d",
+		label:   i18n.GetInstance().T("cli.ui.action.delete"),
+		confirm: true,
+		run:     func(ctx context.Context, id, _ string) error { return run(ctx, id) },
+	}
+}
+
+func renameAction(run func(ctx context.Context, id, name string) error) action {
+	manager := i18n.GetInstance()
+	return action{
+		key:     "n",
+		label:   manager.T("cli.ui.action.rename"),
+		confirm: true,
+		prompt:  manager.T("cli.ui.new_name"),
+		run:     run,
+	}
+}
+
+// headers traduz os títulos das colunas
+func headers(keys ...string) []string {
+	manager := i18n.GetInstance()
+	result := make([]string, len(keys))
+	for i, key := range keys {
+		result[i] = manager.T("cli.ui.column." + key)
+	}
+	return result
+}
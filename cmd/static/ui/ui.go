@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gfcli/beautiful"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/spf13/cobra"
+)
+
+// errQuit encerra o navegador a partir de qualquer tela
+var errQuit = errors.New("quit")
+
+func UICmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	manager := i18n.GetInstance()
+	cmd := &cobra.Command{
+		Use:     "ui",
+		Short:   manager.T("cli.ui.short"),
+		Long:    manager.T("cli.ui.long"),
+		GroupID: "other",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !beautiful.IsInteractive() {
+				return errors.New(manager.T("cli.ui.not_interactive"))
+			}
+
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			b := &browser{
+				ctx:      cmd.Context(),
+				output:   beautiful.NewOutput(raw),
+				products: catalog(sdkCoreConfig),
+			}
+
+			err := b.run()
+			if errors.Is(err, errQuit) {
+				return nil
+			}
+			return err
+		},
+	}
+
+	parent.AddCommand(cmd)
+}
+
+// browser conduz a navegação produto → tipo de recurso → lista → detalhe
+type browser struct {
+	ctx      context.Context
+	output   *beautiful.Output
+	products []product
+}
+
+func (b *browser) run() error {
+	manager := i18n.GetInstance()
+
+	names := make([][]string, len(b.products))
+	for i, p := range b.products {
+		names[i] = []string{p.name}
+	}
+
+	selector := beautiful.NewSelector(b.output, manager.T("cli.ui.title_products"), []string{manager.T("cli.ui.column.product")}, names)
+	for {
+		index, key, err := selector.Run()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case beautiful.SelectorQuit, beautiful.SelectorBack:
+			return errQuit
+		case beautiful.SelectorEnter:
+			if err := b.browseProduct(b.products[index]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (b *browser) browseProduct(p product) error {
+	if len(p.resources) == 1 {
+		return b.browseResource(p, p.resources[0])
+	}
+
+	manager := i18n.GetInstance()
+	names := make([][]string, len(p.resources))
+	for i, r := range p.resources {
+		names[i] = []string{r.name}
+	}
+
+	selector := beautiful.NewSelector(b.output, p.name, []string{manager.T("cli.ui.column.resource")}, names)
+	for {
+		index, key, err := selector.Run()
+		if err != nil {
+			return err
+		}
+		switch key {
+		case beautiful.SelectorQuit:
+			return errQuit
+		case beautiful.SelectorBack:
+			return nil
+		case beautiful.SelectorEnter:
+			if err := b.browseResource(p, p.resources[index]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (b *browser) browseResource(p product, r resource) error {
+	manager := i18n.GetInstance()
+	title := fmt.Sprintf("%s › %s", p.name, r.name)
+
+	hotkeys := []beautiful.Hotkey{{Key: "r", Label: manager.T("cli.ui.action.refresh")}}
+	for _, a := range r.actions {
+		hotkeys = append(hotkeys, beautiful.Hotkey{Key: a.key, Label: a.label})
+	}
+
+	cursor := 0
+	message := ""
+	for {
+		rows, err := r.list(b.ctx)
+		if err != nil {
+			message = manager.T("cli.ui.list_error", err)
+		}
+
+		columns := make([][]string, len(rows))
+		for i, item := range rows {
+			columns[i] = item.columns
+		}
+
+		selector := beautiful.NewSelector(b.output, title, r.headers, columns)
+		selector.Hotkeys = hotkeys
+		selector.Message = message
+		selector.Select(cursor)
+
+		index, key, err := selector.Run()
+		if err != nil {
+			return err
+		}
+		cursor = index
+		message = ""
+
+		switch key {
+		case beautiful.SelectorQuit:
+			return errQuit
+		case beautiful.SelectorBack:
+			return nil
+		case "r":
+			continue
+		case beautiful.SelectorEnter:
+			message = b.showDetail(r, rows[index])
+		default:
+			// O seletor devolve as teclas de ação mesmo com a lista vazia
+			if len(rows) > 0 {
+				message = b.runAction(selector, r, key, rows[index])
+			}
+		}
+	}
+}
+
+// showDetail abre o Get do recurso no explorador JSON
+func (b *browser) showDetail(r resource, item row) string {
+	manager := i18n.GetInstance()
+
+	detail, err := r.get(b.ctx, item.id)
+	if err != nil {
+		return manager.T("cli.ui.get_error", item.name, err)
+	}
+
+	data, err := json.Marshal(detail)
+	if err != nil {
+		return err.Error()
+	}
+
+	explorer := beautiful.NewJSONExplorer(b.output)
+	if err := explorer.ExploreJSON(data); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// runAction executa o atalho escolhido, pedindo valor e confirmação quando necessário
+func (b *browser) runAction(selector *beautiful.Selector, r resource, key string, item row) string {
+	manager := i18n.GetInstance()
+
+	var selected *action
+	for i := range r.actions {
+		if r.actions[i].key == key {
+			selected = &r.actions[i]
+		}
+	}
+	if selected == nil {
+		return ""
+	}
+
+	value := ""
+	if selected.prompt != "" {
+		input, ok, err := selector.Prompt(selected.prompt, item.name)
+		if err != nil {
+			return err.Error()
+		}
+		if !ok || input == "" {
+			return manager.T("cli.ui.cancelled")
+		}
+		value = input
+	}
+
+	if selected.confirm {
+		confirmed, err := selector.Confirm(manager.T("cli.ui.confirm", selected.label, item.name))
+		if err != nil {
+			return err.Error()
+		}
+		if !confirmed {
+			return manager.T("cli.ui.cancelled")
+		}
+	}
+
+	if err := selected.run(b.ctx, item.id, value); err != nil {
+		return manager.T("cli.ui.action_error", selected.label, item.name, err)
+	}
+	return manager.T("cli.ui.action_done", selected.label, item.name)
+}
@@ -0,0 +1,210 @@
+package vm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	computeSdk "github.com/MagaluCloud/mgc-sdk-go/compute"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	followFlag    = "follow"
+	intervalFlag  = "interval"
+	sinceLineFlag = "since-line"
+	highlightFlag = "highlight"
+
+	// minInterval é o menor intervalo aceito entre as consultas do --follow
+	minInterval = time.Second
+
+	// errorPattern é o padrão do --highlight sem valor
+	errorPattern = `(?i)\b(error|errors|fail|failed|failure|fatal|panic|traceback|denied|refused|timed out|timeout)\b`
+)
+
+// terminalStates são os estados em que a instância não produz mais log de inicialização
+var terminalStates = []string{"stopped", "suspended", "deleted", "deleting", "error"}
+
+// initLog acompanha o log de inicialização de uma instância
+type initLog struct {
+	service   computeSdk.InstanceService
+	output    *beautiful.Output
+	highlight *regexp.Regexp
+	// printed são as linhas já exibidas, usadas para achar a sobreposição com a próxima consulta
+	printed []string
+	// line é o número da última linha lida, contado a partir da primeira
+	// consulta. A API só retorna as últimas linhas do log e não informa a
+	// posição delas, então a linha 1 do --since-line é a primeira da janela
+	// da primeira consulta, e não necessariamente a do log.
+	line int
+}
+
+// InitLogCmd adiciona ao 'virtual-machine instances init-log' o --follow, o
+// --since-line e o destaque de erros.
+func InitLogCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	manager := i18n.GetInstance()
+
+	path := []string{"virtual-machine", "instances", "init-log"}
+	cmd, _, err := parent.Find(path)
+	if err != nil || cmd == nil || cmd.Name() != path[len(path)-1] {
+		return
+	}
+
+	cmd.Flags().BoolP(followFlag, "f", false, manager.T("cli.vm_initlog.flag.follow"))
+	cmd.Flags().Duration(intervalFlag, 5*time.Second, manager.T("cli.vm_initlog.flag.interval"))
+	cmd.Flags().Int(sinceLineFlag, 0, manager.T("cli.vm_initlog.flag.since_line"))
+	cmd.Flags().String(highlightFlag, "", manager.T("cli.vm_initlog.flag.highlight"))
+	cmd.Flags().Lookup(highlightFlag).NoOptDefVal = errorPattern
+
+	previous := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		interval, _ := flags.GetDuration(intervalFlag)
+		if interval < minInterval {
+			return fmt.Errorf(manager.T("cli.vm_initlog.invalid_interval"), minInterval)
+		}
+		if !flags.Changed(followFlag) && !flags.Changed(sinceLineFlag) && !flags.Changed(highlightFlag) {
+			return previous(cmd, args)
+		}
+
+		raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+		l := &initLog{
+			service: computeSdk.New(&sdkCoreConfig).Instances(),
+			output:  beautiful.NewOutput(raw),
+		}
+		if pattern, _ := flags.GetString(highlightFlag); pattern != "" {
+			highlight, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf(manager.T("cli.vm_initlog.invalid_pattern"), pattern, err)
+			}
+			// A saída bruta não leva cores
+			if !raw {
+				l.highlight = highlight
+			}
+		}
+
+		id, _ := flags.GetString("id")
+		follow, _ := flags.GetBool(followFlag)
+		sinceLine, _ := flags.GetInt(sinceLineFlag)
+		var maxLines *int
+		if flags.Changed("max-lines") {
+			value, _ := flags.GetInt("max-lines")
+			maxLines = &value
+		}
+
+		if !follow {
+			return l.poll(cmdutils.CommandContext(cmd), id, maxLines, sinceLine)
+		}
+		return l.follow(cmdutils.CommandContext(cmd), id, maxLines, sinceLine, interval)
+	}
+}
+
+// follow consulta o log até o Ctrl-C ou até a instância chegar a um estado final
+func (l *initLog) follow(ctx context.Context, id string, maxLines *int, sinceLine int, interval time.Duration) error {
+	manager := i18n.GetInstance()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	for {
+		if err := l.poll(ctx, id, maxLines, sinceLine); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		instance, err := l.service.Get(ctx, id, nil)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if state, done := terminal(instance); done {
+			// Uma última consulta pega as linhas gravadas antes da parada
+			if err := l.poll(ctx, id, maxLines, sinceLine); err != nil && ctx.Err() == nil {
+				return err
+			}
+			l.output.PrintInfo(manager.T("cli.vm_initlog.stopped", state))
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// poll busca o log e mostra as linhas ainda não exibidas. A API retorna as
+// últimas linhas do log, então a nova consulta é alinhada com o fim da anterior.
+func (l *initLog) poll(ctx context.Context, id string, maxLines *int, sinceLine int) error {
+	manager := i18n.GetInstance()
+
+	response, err := l.service.InitLog(ctx, id, maxLines)
+	if err != nil {
+		return err
+	}
+
+	lines := response.Logs
+	seen := overlap(l.printed, lines)
+	if len(l.printed) > 0 && seen == 0 && len(lines) > 0 {
+		l.output.PrintWarning(manager.T("cli.vm_initlog.gap"))
+	}
+
+	for _, line := range lines[seen:] {
+		l.line++
+		if l.line > sinceLine {
+			fmt.Println(l.colorize(line))
+		}
+	}
+	l.printed = lines
+	return nil
+}
+
+// colorize destaca os trechos que casam com o padrão do --highlight
+func (l *initLog) colorize(line string) string {
+	if l.highlight == nil {
+		return line
+	}
+	mark := color.New(color.FgRed, color.Bold).SprintFunc()
+	return l.highlight.ReplaceAllStringFunc(line, func(match string) string { return mark(match) })
+}
+
+// overlap retorna quantas linhas do início de current repetem o fim de previous
+func overlap(previous, current []string) int {
+	for size := min(len(previous), len(current)); size > 0; size-- {
+		if previous[len(previous)-1] != current[size-1] {
+			continue
+		}
+		if slices.Equal(previous[len(previous)-size:], current[:size]) {
+			return size
+		}
+	}
+	return 0
+}
+
+// terminal indica se a instância chegou a um estado em que não há mais log novo
+func terminal(instance *computeSdk.Instance) (string, bool) {
+	for _, value := range []string{instance.State, instance.Status} {
+		for _, state := range terminalStates {
+			if strings.EqualFold(value, state) {
+				return value, true
+			}
+		}
+	}
+	return "", false
+}
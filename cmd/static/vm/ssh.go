@@ -0,0 +1,259 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gfcli/beautiful"
+	"gfcli/cmd/static/resolve"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	computeSdk "github.com/MagaluCloud/mgc-sdk-go/compute"
+	"github.com/spf13/cobra"
+)
+
+// sshKeyConfigPrefix forma as chaves da configuração que ligam o nome de uma
+// chave SSH da cloud a um arquivo local, como ssh.key.minha-chave
+const sshKeyConfigPrefix = "ssh.key."
+
+// defaultUsers mapeia a família da imagem para o usuário padrão da imagem
+var defaultUsers = []struct{ family, user string }{
+	{"ubuntu", "ubuntu"},
+	{"debian", "debian"},
+	{"rocky", "rocky"},
+	{"almalinux", "almalinux"},
+	{"centos", "centos"},
+	{"fedora", "fedora"},
+	{"oracle", "opc"},
+	{"opensuse", "opensuse"},
+}
+
+// sshOptions são as flags do 'instances ssh'
+type sshOptions struct {
+	user     string
+	identity string
+	port     int
+	private  bool
+	options  []string
+	dryRun   bool
+}
+
+// SSHCmd adiciona o 'virtual-machine instances ssh'.
+func SSHCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	manager := i18n.GetInstance()
+
+	instances := instancesCmd(parent)
+	if instances == nil {
+		return
+	}
+
+	var opts sshOptions
+
+	cmd := &cobra.Command{
+		Use:   "ssh <id|name> [-- command...]",
+		Short: manager.T("cli.vm_ssh.short"),
+		Long:  manager.T("cli.vm_ssh.long"),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := resolve.Resolve(cmd, sdkCoreConfig, "virtual-machine instances", "id", args[0])
+			if err != nil {
+				return err
+			}
+			service := computeSdk.New(&sdkCoreConfig).Instances()
+			expand := []string{computeSdk.InstanceNetworkExpand, computeSdk.InstanceImageExpand}
+			instance, err := service.Get(cmdutils.CommandContext(cmd), id, expand)
+			if err != nil {
+				return err
+			}
+
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			output := beautiful.NewOutput(raw)
+			if !strings.EqualFold(instance.State, "running") {
+				output.PrintWarning(manager.T("cli.vm_ssh.not_running", instanceName(instance), instance.State))
+			}
+
+			sshArgs, err := buildSSHArgs(instance, opts, args[1:], output)
+			if err != nil {
+				return err
+			}
+			if opts.dryRun {
+				fmt.Println(cmdutils.ShellJoin(append([]string{"ssh"}, sshArgs...)))
+				return nil
+			}
+			return runSSH(sshArgs)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.user, "user", "u", "", manager.T("cli.vm_ssh.flag.user"))
+	cmd.Flags().StringVarP(&opts.identity, "identity", "i", "", manager.T("cli.vm_ssh.flag.identity"))
+	cmd.Flags().IntVarP(&opts.port, "port", "p", 22, manager.T("cli.vm_ssh.flag.port"))
+	cmd.Flags().BoolVar(&opts.private, "private", false, manager.T("cli.vm_ssh.flag.private"))
+	cmd.Flags().StringArrayVarP(&opts.options, "ssh-option", "o", nil, manager.T("cli.vm_ssh.flag.ssh_option"))
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, manager.T("cli.vm_ssh.flag.dry_run"))
+
+	instances.AddCommand(cmd)
+}
+
+// buildSSHArgs monta os argumentos do ssh: opções, destino e o comando remoto
+func buildSSHArgs(instance *computeSdk.Instance, opts sshOptions, command []string, output *beautiful.Output) ([]string, error) {
+	host, err := instanceAddress(instance, opts.private)
+	if err != nil {
+		return nil, err
+	}
+
+	user := opts.user
+	if user == "" {
+		if user, err = defaultUser(instance); err != nil {
+			return nil, err
+		}
+	}
+
+	identity := opts.identity
+	if identity == "" {
+		if identity, err = identityFile(instance); err != nil {
+			return nil, err
+		}
+		if identity == "" && instance.SSHKeyName != nil {
+			output.PrintWarning(i18n.GetInstance().T("cli.vm_ssh.key_not_mapped", *instance.SSHKeyName, sshKeyConfigPrefix+*instance.SSHKeyName))
+		}
+	}
+
+	var args []string
+	if identity != "" {
+		args = append(args, "-i", identity)
+	}
+	if opts.port != 22 {
+		args = append(args, "-p", strconv.Itoa(opts.port))
+	}
+	for _, option := range opts.options {
+		args = append(args, "-o", option)
+	}
+	args = append(args, user+"@"+host)
+	return append(args, command...), nil
+}
+
+// instanceAddress escolhe o IP da interface primária: o IPv4 público, o IPv6
+// público na falta dele ou, com --private, o IPv4 privado
+func instanceAddress(instance *computeSdk.Instance, private bool) (string, error) {
+	manager := i18n.GetInstance()
+
+	if instance.Network == nil || instance.Network.Interfaces == nil || len(*instance.Network.Interfaces) == 0 {
+		return "", fmt.Errorf(manager.T("cli.vm_ssh.no_network"), instanceName(instance))
+	}
+
+	interfaces := *instance.Network.Interfaces
+	primary := interfaces[0]
+	for _, item := range interfaces {
+		if item.Primary != nil && *item.Primary {
+			primary = item
+			break
+		}
+	}
+
+	switch {
+	case private && primary.IpAddresses.PrivateIpv4 != "":
+		return primary.IpAddresses.PrivateIpv4, nil
+	case private:
+		return "", fmt.Errorf(manager.T("cli.vm_ssh.no_private_ip"), instanceName(instance))
+	case primary.AssociatedPublicIpv4 != nil && *primary.AssociatedPublicIpv4 != "":
+		return *primary.AssociatedPublicIpv4, nil
+	case primary.IpAddresses.PublicIpv6 != "":
+		return primary.IpAddresses.PublicIpv6, nil
+	}
+	return "", fmt.Errorf(manager.T("cli.vm_ssh.no_public_ip"), instanceName(instance))
+}
+
+// defaultUser deduz o usuário padrão pela família da imagem da instância
+func defaultUser(instance *computeSdk.Instance) (string, error) {
+	manager := i18n.GetInstance()
+
+	if instance.Image == nil {
+		return "", errors.New(manager.T("cli.vm_ssh.unknown_user"))
+	}
+	name := ""
+	if instance.Image.Name != nil {
+		name = strings.ToLower(*instance.Image.Name)
+	}
+	if (instance.Image.Platform != nil && strings.EqualFold(*instance.Image.Platform, "windows")) || strings.Contains(name, "windows") {
+		return "", errors.New(manager.T("cli.vm_ssh.windows"))
+	}
+	for _, item := range defaultUsers {
+		if strings.Contains(name, item.family) {
+			return item.user, nil
+		}
+	}
+	return "", errors.New(manager.T("cli.vm_ssh.unknown_user"))
+}
+
+// identityFile retorna a chave privada ligada ao ssh_key_name da instância:
+// a configurada em ssh.key.<nome> ou ~/.ssh/<nome>, se existir. Sem nenhuma
+// das duas, o ssh usa as suas chaves padrão.
+func identityFile(instance *computeSdk.Instance) (string, error) {
+	manager := i18n.GetInstance()
+
+	if instance.SSHKeyName == nil || *instance.SSHKeyName == "" {
+		return "", nil
+	}
+	name := *instance.SSHKeyName
+
+	config, err := cmdutils.LoadUserConfig()
+	if err != nil {
+		return "", err
+	}
+	if path, ok := config[sshKeyConfigPrefix+name]; ok {
+		path = cmdutils.ExpandHome(path)
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf(manager.T("cli.vm_ssh.key_missing"), path, sshKeyConfigPrefix+name)
+		}
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", nil
+	}
+	path := filepath.Join(home, ".ssh", name)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	return "", nil
+}
+
+// runSSH executa o ssh local ligado ao terminal. O código de saída do ssh
+// vira o código de saída da CLI, como em uma chamada direta ao ssh.
+func runSSH(args []string) error {
+	path, err := exec.LookPath("ssh")
+	if err != nil {
+		return errors.New(i18n.GetInstance().T("cli.vm_ssh.ssh_not_found"))
+	}
+
+	ssh := exec.Command(path, args...)
+	ssh.Stdin, ssh.Stdout, ssh.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	// O Ctrl-C é tratado pelo ssh e pela sessão remota, não pela CLI
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+
+	var exitErr *exec.ExitError
+	if err := ssh.Run(); errors.As(err, &exitErr) {
+		os.Exit(exitErr.ExitCode())
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func instanceName(instance *computeSdk.Instance) string {
+	if instance.Name != nil && *instance.Name != "" {
+		return *instance.Name
+	}
+	return instance.ID
+}
@@ -0,0 +1,296 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	userDataFlag     = "user-data"
+	userDataFileFlag = "user-data-file"
+	userDataVarFlag  = "user-data-var"
+
+	// maxUserData é o tamanho máximo do user-data já em base64 aceito pela API
+	maxUserData = 65535
+
+	// jinjaHeader marca partes que o próprio cloud-init renderiza, por isso
+	// elas não passam pelo template da CLI
+	jinjaHeader = "## template: jinja"
+
+	byteOrderMark = "\ufeff"
+)
+
+// partTypes mapeia o cabeçalho de cada formato do cloud-init para o tipo MIME da parte
+var partTypes = []struct{ header, contentType string }{
+	{"#cloud-config", "text/cloud-config"},
+	{"#!", "text/x-shellscript"},
+	{"#include", "text/x-include-url"},
+	{"#cloud-boothook", "text/cloud-boothook"},
+	{"#part-handler", "text/part-handler"},
+	{"Content-Type:", "multipart/mixed"},
+}
+
+// userDataPart é um arquivo de user-data antes da composição
+type userDataPart struct {
+	name    string
+	content string
+}
+
+// UserDataCmd adiciona ao 'virtual-machine instances create' a leitura do
+// user-data de arquivos, com template, validação, composição MIME de vários
+// arquivos e codificação base64.
+func UserDataCmd(parent *cobra.Command) {
+	manager := i18n.GetInstance()
+
+	path := []string{"virtual-machine", "instances", "create"}
+	cmd, _, err := parent.Find(path)
+	if err != nil || cmd == nil || cmd.Name() != path[len(path)-1] {
+		return
+	}
+
+	cmd.Flags().StringArray(userDataFileFlag, nil, manager.T("cli.vm_userdata.flag.file"))
+	cmd.Flags().StringToString(userDataVarFlag, nil, manager.T("cli.vm_userdata.flag.var"))
+	cmd.MarkFlagsMutuallyExclusive(userDataFlag, userDataFileFlag)
+
+	previous := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		files, _ := cmd.Flags().GetStringArray(userDataFileFlag)
+		inline, _ := cmd.Flags().GetString(userDataFlag)
+
+		var parts []userDataPart
+		switch {
+		case len(files) > 0:
+			for _, file := range files {
+				data, err := readUserDataFile(file)
+				if err != nil {
+					return err
+				}
+				parts = append(parts, userDataPart{name: filepath.Base(file), content: data})
+			}
+		case cmd.Flags().Changed(userDataFlag) && !isEncoded(inline):
+			parts = []userDataPart{{name: userDataFlag, content: strings.TrimPrefix(inline, byteOrderMark)}}
+		default:
+			return previous(cmd, args)
+		}
+
+		raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+		encoded, err := buildUserData(parts, templateVars(cmd), beautiful.NewOutput(raw))
+		if err != nil {
+			return err
+		}
+		if err := cmd.Flags().Set(userDataFlag, encoded); err != nil {
+			return err
+		}
+		return previous(cmd, args)
+	}
+}
+
+// buildUserData aplica o template e valida cada parte, compõe as partes em
+// uma mensagem MIME quando há mais de uma e codifica o resultado em base64
+func buildUserData(parts []userDataPart, vars map[string]string, output *beautiful.Output) (string, error) {
+	manager := i18n.GetInstance()
+
+	for i, part := range parts {
+		rendered, err := render(part, vars)
+		if err != nil {
+			return "", err
+		}
+		if err := validate(userDataPart{name: part.name, content: rendered}, output); err != nil {
+			return "", err
+		}
+		parts[i].content = rendered
+	}
+
+	payload := parts[0].content
+	if len(parts) > 1 {
+		var err error
+		if payload, err = multipartUserData(parts); err != nil {
+			return "", err
+		}
+	}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(payload))
+	if len(encoded) > maxUserData {
+		return "", fmt.Errorf(manager.T("cli.vm_userdata.too_large"), len(encoded), maxUserData)
+	}
+	return encoded, nil
+}
+
+// templateVars reúne as variáveis do template: os dados da instância e as de --user-data-var
+func templateVars(cmd *cobra.Command) map[string]string {
+	vars := make(map[string]string)
+	for variable, flags := range map[string][]string{
+		"name":              {"name"},
+		"availability_zone": {"availability-zone"},
+		"machine_type":      {"machine-type.name", "machine-type.id"},
+		"image":             {"image.name", "image.id"},
+		"ssh_key_name":      {"ssh-key-name"},
+	} {
+		vars[variable] = ""
+		for _, flag := range flags {
+			if value, _ := cmd.Flags().GetString(flag); value != "" {
+				vars[variable] = value
+				break
+			}
+		}
+	}
+
+	custom, _ := cmd.Flags().GetStringToString(userDataVarFlag)
+	for key, value := range custom {
+		vars[key] = value
+	}
+	return vars
+}
+
+// render aplica o template com as variáveis. Partes Jinja são renderizadas
+// pelo cloud-init na instância e por isso são mantidas como estão.
+func render(part userDataPart, vars map[string]string) (string, error) {
+	manager := i18n.GetInstance()
+
+	if strings.HasPrefix(part.content, jinjaHeader) || !strings.Contains(part.content, "{{") {
+		return part.content, nil
+	}
+
+	tmpl, err := template.New(part.name).Option("missingkey=error").Parse(part.content)
+	if err != nil {
+		return "", fmt.Errorf(manager.T("cli.vm_userdata.template_failed"), part.name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf(manager.T("cli.vm_userdata.template_failed"), part.name, err)
+	}
+	return buf.String(), nil
+}
+
+// validate confere o formato de uma parte: o YAML do #cloud-config e o
+// interpretador dos scripts
+func validate(part userDataPart, output *beautiful.Output) error {
+	manager := i18n.GetInstance()
+
+	content := part.content
+	if strings.Contains(content, "\r\n") {
+		output.PrintWarning(manager.T("cli.vm_userdata.crlf", part.name))
+	}
+
+	// Partes Jinja só têm o conteúdo final depois de renderizadas na
+	// instância, então apenas o cabeçalho abaixo do Jinja é conferido
+	jinja := strings.HasPrefix(content, jinjaHeader)
+	if jinja {
+		_, content, _ = strings.Cut(content, "\n")
+	}
+
+	switch kind := contentType(content); {
+	case kind == "":
+		return fmt.Errorf(manager.T("cli.vm_userdata.unknown_format"), part.name)
+	case jinja:
+		return nil
+	case kind == "text/cloud-config":
+		var document interface{}
+		if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+			return fmt.Errorf(manager.T("cli.vm_userdata.invalid_yaml"), part.name, err)
+		}
+		if _, ok := document.(map[string]interface{}); !ok && document != nil {
+			return fmt.Errorf(manager.T("cli.vm_userdata.not_mapping"), part.name)
+		}
+	case kind == "text/x-shellscript":
+		line, _, _ := strings.Cut(content, "\n")
+		if strings.TrimSpace(strings.TrimPrefix(line, "#!")) == "" {
+			return fmt.Errorf(manager.T("cli.vm_userdata.no_interpreter"), part.name)
+		}
+	}
+	return nil
+}
+
+// multipartUserData compõe as partes em uma mensagem multipart/mixed, o
+// formato do cloud-init para combinar vários arquivos
+func multipartUserData(parts []userDataPart) (string, error) {
+	manager := i18n.GetInstance()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range parts {
+		content := part.content
+		typeOf := content
+		if strings.HasPrefix(content, jinjaHeader) {
+			_, typeOf, _ = strings.Cut(content, "\n")
+		}
+		kind := contentType(typeOf)
+		if kind == "multipart/mixed" {
+			return "", fmt.Errorf(manager.T("cli.vm_userdata.nested_multipart"), part.name)
+		}
+		if strings.HasPrefix(content, jinjaHeader) {
+			kind = "text/jinja2"
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", kind+`; charset="utf-8"`)
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Transfer-Encoding", "8bit")
+		header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", part.name))
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\nMIME-Version: 1.0\n\n", writer.Boundary()) + body.String(), nil
+}
+
+// contentType retorna o tipo MIME pelo cabeçalho da parte, ou vazio se ele é desconhecido
+func contentType(content string) string {
+	for _, item := range partTypes {
+		if strings.HasPrefix(content, item.header) {
+			return item.contentType
+		}
+	}
+	return ""
+}
+
+// isEncoded indica se o --user-data já está em base64, caso em que é enviado sem alterações
+func isEncoded(value string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil || len(decoded) == 0 {
+		return false
+	}
+	// gzip também é aceito pelo cloud-init
+	if bytes.HasPrefix(decoded, []byte{0x1f, 0x8b}) {
+		return true
+	}
+	return strings.HasPrefix(string(decoded), jinjaHeader) || contentType(string(decoded)) != ""
+}
+
+// readUserDataFile lê um arquivo de user-data; "-" lê da entrada padrão
+func readUserDataFile(path string) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		return strings.TrimPrefix(string(data), byteOrderMark), err
+	}
+	data, err := os.ReadFile(cmdutils.ExpandHome(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf(i18n.GetInstance().T("cli.vm_userdata.file_not_found"), path)
+	}
+	// O BOM de editores do Windows esconderia o cabeçalho do cloud-init
+	return strings.TrimPrefix(string(data), byteOrderMark), err
+}
@@ -0,0 +1,12 @@
+package vm
+
+import "github.com/spf13/cobra"
+
+// instancesCmd encontra o grupo gerado 'virtual-machine instances'
+func instancesCmd(parent *cobra.Command) *cobra.Command {
+	cmd, _, err := parent.Find([]string{"virtual-machine", "instances"})
+	if err != nil || cmd == nil || cmd.Name() != "instances" {
+		return nil
+	}
+	return cmd
+}
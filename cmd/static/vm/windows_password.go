@@ -0,0 +1,138 @@
+package vm
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	computeSdk "github.com/MagaluCloud/mgc-sdk-go/compute"
+	"github.com/spf13/cobra"
+)
+
+const (
+	privateKeyFlag   = "private-key"
+	showSecretsFlag  = "show-secrets"
+	passwordFileFlag = "password-file"
+)
+
+// WindowsPasswordCmd adiciona ao 'virtual-machine instances get-first-windows-password'
+// a decifragem local da senha. A chave privada é lida só localmente e nunca
+// é enviada à API.
+func WindowsPasswordCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	manager := i18n.GetInstance()
+
+	path := []string{"virtual-machine", "instances", "get-first-windows-password"}
+	cmd, _, err := parent.Find(path)
+	if err != nil || cmd == nil || cmd.Name() != path[len(path)-1] {
+		return
+	}
+
+	cmd.Flags().String(privateKeyFlag, "", manager.T("cli.vm_password.flag.private_key"))
+	cmd.Flags().Bool(showSecretsFlag, false, manager.T("cli.vm_password.flag.show_secrets"))
+	cmd.Flags().String(passwordFileFlag, "", manager.T("cli.vm_password.flag.password_file"))
+
+	previous := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		keyPath, _ := cmd.Flags().GetString(privateKeyFlag)
+		if keyPath == "" {
+			return previous(cmd, args)
+		}
+
+		show, _ := cmd.Flags().GetBool(showSecretsFlag)
+		passwordFile, _ := cmd.Flags().GetString(passwordFileFlag)
+		if !show && passwordFile == "" {
+			return errors.New(manager.T("cli.vm_password.output_required"))
+		}
+
+		// A chave é lida antes da chamada à API para falhar cedo com a senha errada
+		key, err := loadRSAKey(cmdutils.ExpandHome(keyPath))
+		if err != nil {
+			return err
+		}
+
+		id, _ := cmd.Flags().GetString("id")
+		service := computeSdk.New(&sdkCoreConfig).Instances()
+		response, err := service.GetFirstWindowsPassword(cmdutils.CommandContext(cmd), id)
+		if err != nil {
+			return err
+		}
+		if response.Instance.Password == "" {
+			return errors.New(manager.T("cli.vm_password.not_ready"))
+		}
+
+		password, err := decryptPassword(key, response.Instance.Password)
+		if err != nil {
+			return err
+		}
+
+		if passwordFile != "" {
+			if err := cmdutils.WriteSecret(cmdutils.ExpandHome(passwordFile), password); err != nil {
+				return err
+			}
+			raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+			beautiful.NewOutput(raw).PrintSuccess(manager.T("cli.vm_password.saved", response.Instance.User, passwordFile))
+		}
+		if show {
+			fmt.Println(password)
+		}
+		return nil
+	}
+}
+
+// loadRSAKey lê a chave privada RSA, pedindo a senha da chave no terminal quando ela é cifrada
+func loadRSAKey(path string) (*rsa.PrivateKey, error) {
+	manager := i18n.GetInstance()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase := func() ([]byte, error) {
+		if !beautiful.IsInteractive() {
+			return nil, fmt.Errorf(manager.T("cli.vm_password.passphrase_required"), path)
+		}
+		secret, err := beautiful.AskSecret(manager.T("cli.vm_password.passphrase", path))
+		return []byte(secret), err
+	}
+	parsed, err := cmdutils.ParsePrivateKey(data, passphrase)
+	if errors.Is(err, cmdutils.ErrWrongPassphrase) {
+		return nil, fmt.Errorf(manager.T("cli.vm_password.wrong_passphrase"), path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(manager.T("cli.vm_password.invalid_key"), path, err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf(manager.T("cli.vm_password.not_rsa"), path)
+	}
+	return key, nil
+}
+
+// decryptPassword decifra a senha em base64 retornada pela API. A senha é
+// cifrada com RSA PKCS#1 v1.5; OAEP é aceito para imagens que o utilizam.
+func decryptPassword(key *rsa.PrivateKey, encrypted string) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encrypted))
+	if err != nil {
+		return "", errors.New(i18n.GetInstance().T("cli.vm_password.not_encrypted"))
+	}
+
+	plain, err := rsa.DecryptPKCS1v15(rand.Reader, key, ciphertext)
+	if err != nil {
+		if plain, err = rsa.DecryptOAEP(sha1.New(), rand.Reader, key, ciphertext, nil); err != nil {
+			return "", errors.New(i18n.GetInstance().T("cli.vm_password.wrong_key"))
+		}
+	}
+	return string(plain), nil
+}
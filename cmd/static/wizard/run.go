@@ -0,0 +1,250 @@
+package wizard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// mutuallyExclusiveAnnotation é a anotação em que o
+// MarkFlagsMutuallyExclusive do cobra guarda os grupos de cada flag
+const mutuallyExclusiveAnnotation = "cobra_annotation_mutually_exclusive"
+
+// wizard pergunta o valor de cada flag de um comando de criação
+type wizard struct {
+	command command
+}
+
+// run conduz as perguntas, exibe o resumo e pede confirmação. As respostas
+// são gravadas nas próprias flags, então o RunE gerado monta a requisição
+// exatamente como faria com a linha de comando equivalente.
+func (w *wizard) run(cmd *cobra.Command) error {
+	manager := i18n.GetInstance()
+	if !beautiful.IsInteractive() {
+		return errors.New(manager.T("cli.wizard.not_interactive"))
+	}
+
+	raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+	output := beautiful.NewOutput(raw)
+	output.PrintHeader(manager.T("cli.wizard.title", cmd.CommandPath()))
+
+	ctx := cmdutils.CommandContext(cmd)
+
+	fields := localFlags(cmd)
+	for _, flag := range fields {
+		if flag.Changed || excluded(cmd, flag) {
+			continue
+		}
+		if err := w.ask(ctx, output, cmd, flag); err != nil {
+			if errors.Is(err, beautiful.ErrCancelled) {
+				return errors.New(manager.T("cli.wizard.cancelled"))
+			}
+			return err
+		}
+	}
+
+	var rows [][]string
+	for _, flag := range fields {
+		if flag.Changed {
+			rows = append(rows, []string{"--" + flag.Name, displayValue(flag)})
+		}
+	}
+	fmt.Println()
+	output.PrintTable([]string{manager.T("cli.wizard.column.flag"), manager.T("cli.wizard.column.value")}, rows)
+	output.PrintInfo(manager.T("cli.wizard.equivalent"))
+	fmt.Println(commandLine(cmd, fields))
+	fmt.Println()
+
+	confirmed, err := beautiful.AskConfirm(manager.T("cli.wizard.confirm"))
+	if err != nil && !errors.Is(err, beautiful.ErrCancelled) {
+		return err
+	}
+	if !confirmed {
+		return errors.New(manager.T("cli.wizard.cancelled"))
+	}
+	return nil
+}
+
+// ask pergunta o valor de uma flag até que ele seja aceito
+func (w *wizard) ask(ctx context.Context, output *beautiful.Output, cmd *cobra.Command, flag *pflag.Flag) error {
+	manager := i18n.GetInstance()
+	required := isRequired(flag)
+	label := flag.Name
+	if required {
+		label = manager.T("cli.wizard.required", flag.Name)
+	}
+
+	for {
+		value, err := w.value(ctx, output, cmd, flag, label, required)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			if !required {
+				return nil
+			}
+			output.PrintWarning(manager.T("cli.wizard.value_required", flag.Name))
+			continue
+		}
+		if err := cmd.Flags().Set(flag.Name, value); err != nil {
+			output.PrintWarning(manager.T("cli.wizard.invalid_value", flag.Name, err))
+			continue
+		}
+		return nil
+	}
+}
+
+// value obtém a resposta de uma flag conforme o seu tipo e a existência de
+// uma lista de valores possíveis
+func (w *wizard) value(ctx context.Context, output *beautiful.Output, cmd *cobra.Command, flag *pflag.Flag, label string, required bool) (string, error) {
+	manager := i18n.GetInstance()
+
+	if build, ok := w.command.builders[flag.Name]; ok {
+		output.PrintInfo(label)
+		return build(ctx, output, cmd)
+	}
+
+	if src, ok := w.command.sources[flag.Name]; ok {
+		value, err := choose(ctx, output, cmd, label, src, required, flag.DefValue)
+		if !errors.Is(err, errNoOptions) {
+			if err == nil && value != "" {
+				fmt.Printf("%s: %s\n", label, value)
+			}
+			return value, err
+		}
+	}
+
+	switch flag.Value.Type() {
+	case "bool":
+		confirmed, err := beautiful.AskConfirm(label)
+		return strconv.FormatBool(confirmed), err
+	case "stringSlice":
+		return beautiful.Ask(manager.T("cli.wizard.hint_list", label), "")
+	case "stringToString":
+		return beautiful.Ask(manager.T("cli.wizard.hint_map", label), "")
+	case "json", "json-array":
+		return beautiful.Ask(manager.T("cli.wizard.hint_json", label), "")
+	}
+
+	if slices.Contains(cmdutils.SecretFlags, flag.Name) {
+		return beautiful.AskSecret(label)
+	}
+
+	initial := flag.DefValue
+	if initial == "0" {
+		initial = ""
+	}
+	return beautiful.Ask(label, initial)
+}
+
+var errNoOptions = errors.New("no options")
+
+// choose busca os valores possíveis de uma flag e exibe um seletor. Quando a
+// lista não pode ser obtida ou está vazia retorna errNoOptions, para que a
+// pergunta seja feita como texto livre.
+func choose(ctx context.Context, output *beautiful.Output, cmd *cobra.Command, label string, src source, required bool, current string) (string, error) {
+	manager := i18n.GetInstance()
+
+	options, err := src.list(ctx, cmd)
+	if err != nil {
+		output.PrintWarning(manager.T("cli.wizard.list_error", label, err))
+		return "", errNoOptions
+	}
+	if len(options) == 0 {
+		return "", errNoOptions
+	}
+
+	if !required {
+		skip := make([]string, len(src.headers))
+		skip[0] = manager.T("cli.wizard.skip")
+		options = append([]option{{columns: skip}}, options...)
+	}
+
+	rows := make([][]string, len(options))
+	initial := 0
+	for i, opt := range options {
+		rows[i] = opt.columns
+		if current != "" && opt.value == current {
+			initial = i
+		}
+	}
+
+	index, err := beautiful.Choose(output, label, src.headers, rows, initial)
+	if err != nil {
+		return "", err
+	}
+	return options[index].value, nil
+}
+
+// localFlags retorna as flags próprias do comando na ordem em que foram
+// declaradas, que é a ordem dos campos da requisição do SDK
+func localFlags(cmd *cobra.Command) []*pflag.Flag {
+	flags := cmd.Flags()
+	sorted := flags.SortFlags
+	flags.SortFlags = false
+	defer func() { flags.SortFlags = sorted }()
+
+	inherited := cmd.InheritedFlags()
+	var result []*pflag.Flag
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Name == "help" || flag.Name == interactiveFlag || inherited.Lookup(flag.Name) != nil {
+			return
+		}
+		if _, skip := flag.Annotations[cmdutils.WizardSkipAnnotation]; skip {
+			return
+		}
+		result = append(result, flag)
+	})
+	return result
+}
+
+// excluded indica se outra flag de um mesmo grupo mutuamente exclusivo já
+// recebeu valor. O cobra só confere os grupos antes do PreRunE, então uma
+// resposta do assistente que violasse o grupo passaria sem erro.
+func excluded(cmd *cobra.Command, flag *pflag.Flag) bool {
+	for _, group := range flag.Annotations[mutuallyExclusiveAnnotation] {
+		for _, name := range strings.Fields(group) {
+			if other := cmd.Flags().Lookup(name); other != nil && other != flag && other.Changed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isRequired(flag *pflag.Flag) bool {
+	values := flag.Annotations[cobra.BashCompOneRequiredFlag]
+	return len(values) > 0 && values[0] == "true"
+}
+
+// displayValue formata o valor de uma flag para o resumo, ocultando segredos
+func displayValue(flag *pflag.Flag) string {
+	if slices.Contains(cmdutils.SecretFlags, flag.Name) {
+		return cmdutils.Redacted
+	}
+	if slice, ok := flag.Value.(pflag.SliceValue); ok {
+		return strings.Join(slice.GetSlice(), ",")
+	}
+	return flag.Value.String()
+}
+
+// commandLine monta o comando não interativo equivalente às respostas
+func commandLine(cmd *cobra.Command, fields []*pflag.Flag) string {
+	parts := []string{cmd.CommandPath()}
+	for _, flag := range fields {
+		if flag.Changed {
+			parts = append(parts, fmt.Sprintf("--%s=%s", flag.Name, cmdutils.ShellQuote(displayValue(flag))))
+		}
+	}
+	return strings.Join(parts, " ")
+}
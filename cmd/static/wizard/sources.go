@@ -0,0 +1,336 @@
+package wizard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gfcli/beautiful"
+	cmdutils "gfcli/cmd_utils"
+	"gfcli/i18n"
+
+	azSdk "github.com/MagaluCloud/mgc-sdk-go/availabilityzones"
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	computeSdk "github.com/MagaluCloud/mgc-sdk-go/compute"
+	dbaasSdk "github.com/MagaluCloud/mgc-sdk-go/dbaas"
+	kubernetesSdk "github.com/MagaluCloud/mgc-sdk-go/kubernetes"
+	networkSdk "github.com/MagaluCloud/mgc-sdk-go/network"
+	sshkeysSdk "github.com/MagaluCloud/mgc-sdk-go/sshkeys"
+	"github.com/spf13/cobra"
+)
+
+// option é um valor possível de uma flag, com as colunas exibidas no seletor
+type option struct {
+	value   string
+	columns []string
+}
+
+// source busca os valores possíveis de uma flag. O comando é recebido para
+// que a lista possa ser filtrada pelas respostas anteriores.
+type source struct {
+	headers []string
+	list    func(ctx context.Context, cmd *cobra.Command) ([]option, error)
+}
+
+// builder monta o valor de uma flag composta fazendo várias perguntas
+type builder func(ctx context.Context, output *beautiful.Output, cmd *cobra.Command) (string, error)
+
+func headers(keys ...string) []string {
+	manager := i18n.GetInstance()
+	result := make([]string, len(keys))
+	for i, key := range keys {
+		result[i] = manager.T("cli.wizard.column." + key)
+	}
+	return result
+}
+
+func fixed(values []string) source {
+	return source{
+		headers: headers("value"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			options := make([]option, len(values))
+			for i, value := range values {
+				options[i] = option{value: value, columns: []string{value}}
+			}
+			return options, nil
+		},
+	}
+}
+
+func machineTypes(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("name", "vcpus", "ram", "disk"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			opts := computeSdk.InstanceTypeListOptions{}
+			if zone, _ := cmd.Flags().GetString("availability-zone"); zone != "" {
+				opts.AvailabilityZone = zone
+			}
+			items, err := computeSdk.New(&sdkCoreConfig).InstanceTypes().List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			var options []option
+			for _, item := range items {
+				if item.Status != "" && item.Status != "active" {
+					continue
+				}
+				options = append(options, option{
+					value:   item.Name,
+					columns: []string{item.Name, strconv.Itoa(item.VCPUs), fmt.Sprintf("%d MB", item.RAM), fmt.Sprintf("%d GB", item.Disk)},
+				})
+			}
+			return options, nil
+		},
+	}
+}
+
+func images(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("name", "version", "platform"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			items, err := computeSdk.New(&sdkCoreConfig).Images().List(ctx, computeSdk.ImageListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			var options []option
+			for _, item := range items {
+				if item.Status != computeSdk.ImageStatusActive {
+					continue
+				}
+				options = append(options, option{
+					value:   item.Name,
+					columns: []string{item.Name, cmdutils.Deref(item.Version), cmdutils.Deref(item.Platform)},
+				})
+			}
+			return options, nil
+		},
+	}
+}
+
+func availabilityZones(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("availability_zone", "region"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			regions, err := azSdk.New(&sdkCoreConfig).AvailabilityZones().List(ctx, azSdk.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			var options []option
+			for _, region := range regions {
+				for _, zone := range region.AvailabilityZones {
+					if zone.BlockType == azSdk.BlockTypeTotal {
+						continue
+					}
+					options = append(options, option{value: zone.ID, columns: []string{zone.ID, region.ID}})
+				}
+			}
+			return options, nil
+		},
+	}
+}
+
+func sshKeys(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("name", "key_type"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			keys, err := sshkeysSdk.New(&sdkCoreConfig).Keys().List(ctx, sshkeysSdk.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			options := make([]option, len(keys))
+			for i, key := range keys {
+				options[i] = option{value: key.Name, columns: []string{key.Name, key.KeyType}}
+			}
+			return options, nil
+		},
+	}
+}
+
+func dbaasEngines(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("name", "version", "id"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			status := "ACTIVE"
+			engines, err := dbaasSdk.New(&sdkCoreConfig).Engines().List(ctx, dbaasSdk.ListEngineOptions{Status: &status})
+			if err != nil {
+				return nil, err
+			}
+			options := make([]option, len(engines))
+			for i, engine := range engines {
+				options[i] = option{value: engine.ID, columns: []string{engine.Name, engine.Version, engine.ID}}
+			}
+			return options, nil
+		},
+	}
+}
+
+func dbaasInstanceTypes(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("name", "vcpus", "ram", "id"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			opts := dbaasSdk.ListInstanceTypeOptions{}
+			if engineID, _ := cmd.Flags().GetString("engine-id"); engineID != "" {
+				opts.EngineID = &engineID
+			}
+			types, err := dbaasSdk.New(&sdkCoreConfig).InstanceTypes().List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			options := make([]option, len(types))
+			for i, item := range types {
+				options[i] = option{value: item.ID, columns: []string{item.Label, item.VCPU, item.RAM, item.ID}}
+			}
+			return options, nil
+		},
+	}
+}
+
+func dbaasParameterGroups(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("name", "type", "id"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			opts := dbaasSdk.ListParameterGroupsOptions{}
+			if engineID, _ := cmd.Flags().GetString("engine-id"); engineID != "" {
+				opts.EngineID = &engineID
+			}
+			groups, err := dbaasSdk.New(&sdkCoreConfig).ParametersGroup().List(ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			options := make([]option, len(groups))
+			for i, group := range groups {
+				options[i] = option{value: group.ID, columns: []string{group.Name, string(group.Type), group.ID}}
+			}
+			return options, nil
+		},
+	}
+}
+
+func kubernetesVersions(sdkCoreConfig sdk.CoreClient) source {
+	manager := i18n.GetInstance()
+	return source{
+		headers: headers("version", "status"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			versions, err := kubernetesSdk.New(&sdkCoreConfig).Versions().List(ctx)
+			if err != nil {
+				return nil, err
+			}
+			options := make([]option, len(versions))
+			for i, version := range versions {
+				status := ""
+				if version.Deprecated {
+					status = manager.T("cli.wizard.deprecated")
+				}
+				options[i] = option{value: version.Version, columns: []string{version.Version, status}}
+			}
+			return options, nil
+		},
+	}
+}
+
+func kubernetesFlavors(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("name", "vcpus", "ram", "disk"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			flavors, err := kubernetesSdk.New(&sdkCoreConfig).Flavors().List(ctx, kubernetesSdk.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			options := make([]option, len(flavors.NodePool))
+			for i, flavor := range flavors.NodePool {
+				options[i] = option{
+					value:   flavor.Name,
+					columns: []string{flavor.Name, strconv.Itoa(flavor.VCPU), fmt.Sprintf("%d MB", flavor.RAM), fmt.Sprintf("%d GB", flavor.Size)},
+				}
+			}
+			return options, nil
+		},
+	}
+}
+
+func vpcs(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("name", "status", "id"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			items, err := networkSdk.New(&sdkCoreConfig).VPCs().List(ctx)
+			if err != nil {
+				return nil, err
+			}
+			options := make([]option, len(items))
+			for i, item := range items {
+				options[i] = option{value: cmdutils.Deref(item.ID), columns: []string{cmdutils.Deref(item.Name), item.Status, cmdutils.Deref(item.ID)}}
+			}
+			return options, nil
+		},
+	}
+}
+
+func subnetPools(sdkCoreConfig sdk.CoreClient) source {
+	return source{
+		headers: headers("name", "cidr", "id"),
+		list: func(ctx context.Context, cmd *cobra.Command) ([]option, error) {
+			pools, err := networkSdk.New(&sdkCoreConfig).SubnetPools().List(ctx, networkSdk.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			options := make([]option, len(pools))
+			for i, pool := range pools {
+				options[i] = option{value: pool.ID, columns: []string{pool.Name, cmdutils.Deref(pool.CIDR), pool.ID}}
+			}
+			return options, nil
+		},
+	}
+}
+
+// nodePools monta a lista JSON de node pools perguntando nome, flavor e
+// quantidade de nós de cada um
+func nodePools(flavors source) builder {
+	manager := i18n.GetInstance()
+	return func(ctx context.Context, output *beautiful.Output, cmd *cobra.Command) (string, error) {
+		var pools []kubernetesSdk.CreateNodePoolRequest
+		for {
+			add, err := beautiful.AskConfirm(manager.T("cli.wizard.node_pool_add"))
+			if err != nil {
+				return "", err
+			}
+			if !add {
+				break
+			}
+
+			name, err := beautiful.Ask(manager.T("cli.wizard.node_pool_name"), fmt.Sprintf("pool-%d", len(pools)+1))
+			if err != nil {
+				return "", err
+			}
+
+			flavor, err := choose(ctx, output, cmd, manager.T("cli.wizard.node_pool_flavor"), flavors, true, "")
+			if errors.Is(err, errNoOptions) {
+				flavor, err = beautiful.Ask(manager.T("cli.wizard.node_pool_flavor"), "")
+			}
+			if err != nil {
+				return "", err
+			}
+
+			replicas := 0
+			for replicas < 1 {
+				answer, err := beautiful.Ask(manager.T("cli.wizard.node_pool_replicas"), "1")
+				if err != nil {
+					return "", err
+				}
+				replicas, _ = strconv.Atoi(answer)
+			}
+
+			pools = append(pools, kubernetesSdk.CreateNodePoolRequest{Name: name, Flavor: flavor, Replicas: replicas})
+		}
+
+		if len(pools) == 0 {
+			return "", nil
+		}
+		data, err := json.Marshal(pools)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
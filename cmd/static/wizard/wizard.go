@@ -0,0 +1,98 @@
+package wizard
+
+import (
+	"gfcli/beautiful"
+	"gfcli/i18n"
+
+	sdk "github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/spf13/cobra"
+)
+
+const interactiveFlag = "interactive"
+
+// command descreve um comando gerado que ganha o modo assistente
+type command struct {
+	path    []string
+	sources map[string]source
+	// builders montam flags compostas, como listas JSON, a partir de perguntas menores
+	builders map[string]builder
+}
+
+// WizardCmd adiciona a flag --interactive aos comandos de criação gerados.
+func WizardCmd(parent *cobra.Command, sdkCoreConfig sdk.CoreClient) {
+	manager := i18n.GetInstance()
+
+	for _, c := range commands(sdkCoreConfig) {
+		cmd, _, err := parent.Find(c.path)
+		if err != nil || cmd == nil || cmd.Name() != c.path[len(c.path)-1] {
+			continue
+		}
+
+		cmd.Flags().Bool(interactiveFlag, false, manager.T("cli.wizard.flag"))
+
+		w := &wizard{command: c}
+		previous := cmd.PreRunE
+		cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+			if previous != nil {
+				if err := previous(cmd, args); err != nil {
+					return err
+				}
+			}
+			interactive, _ := cmd.Flags().GetBool(interactiveFlag)
+			if !interactive {
+				return nil
+			}
+			// Erros do PreRunE não passam pelo tratamento do RunE, então são exibidos aqui
+			cmd.SilenceUsage = true
+			if err := w.run(cmd); err != nil {
+				raw, _ := cmd.Root().PersistentFlags().GetBool("raw")
+				beautiful.NewOutput(raw).PrintError(err.Error(), true)
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// commands lista os comandos de criação atendidos pelo assistente e de onde
+// vêm os valores enumeráveis de cada flag
+func commands(sdkCoreConfig sdk.CoreClient) []command {
+	return []command{
+		{
+			path: []string{"virtual-machine", "instances", "create"},
+			sources: map[string]source{
+				"machine-type.name": machineTypes(sdkCoreConfig),
+				"image.name":        images(sdkCoreConfig),
+				"availability-zone": availabilityZones(sdkCoreConfig),
+				"ssh-key-name":      sshKeys(sdkCoreConfig),
+			},
+		},
+		{
+			path: []string{"dbaas", "instances", "create"},
+			sources: map[string]source{
+				"engine-id":          dbaasEngines(sdkCoreConfig),
+				"instance-type-id":   dbaasInstanceTypes(sdkCoreConfig),
+				"availability-zone":  availabilityZones(sdkCoreConfig),
+				"parameter-group-id": dbaasParameterGroups(sdkCoreConfig),
+			},
+		},
+		{
+			path: []string{"kubernetes", "clusters", "create"},
+			sources: map[string]source{
+				"version": kubernetesVersions(sdkCoreConfig),
+			},
+			builders: map[string]builder{
+				"node-pools": nodePools(kubernetesFlavors(sdkCoreConfig)),
+			},
+		},
+		{
+			path: []string{"network", "v-p-cs", "create-subnet"},
+			sources: map[string]source{
+				"vpc-id":         vpcs(sdkCoreConfig),
+				"subnet-pool-id": subnetPools(sdkCoreConfig),
+				"i-p-version":    fixed([]string{"4", "6"}),
+				"zone":           availabilityZones(sdkCoreConfig),
+			},
+		},
+	}
+}
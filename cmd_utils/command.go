@@ -0,0 +1,39 @@
+package cmdutils
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// WizardSkipAnnotation marca as flags que o --interactive não pergunta, como
+// as que leem a entrada padrão ou só fazem sentido junto de outra flag
+const WizardSkipAnnotation = "gfcli_wizard_skip"
+
+// CommandContext retorna o contexto do comando, ou um contexto vazio quando
+// o comando é executado sem um
+func CommandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// Subcommand encontra um subcomando gerado pelo nome
+func Subcommand(parent *cobra.Command, name string) *cobra.Command {
+	for _, cmd := range parent.Commands() {
+		if cmd.Name() == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// Deref retorna o valor apontado, ou o valor zero quando o ponteiro é nulo
+func Deref[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
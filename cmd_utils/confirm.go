@@ -0,0 +1,32 @@
+package cmdutils
+
+import (
+	"errors"
+
+	"gfcli/beautiful"
+	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
+)
+
+// Confirm pede confirmação antes de uma alteração, a menos que --no-confirm
+// tenha sido usado. Fora de um terminal a confirmação é obrigatória e o erro
+// traz a mensagem de requiredKey; a recusa traz a de cancelledKey.
+func Confirm(cmd *cobra.Command, question, requiredKey, cancelledKey string) error {
+	manager := i18n.GetInstance()
+
+	if noConfirm, _ := cmd.Root().PersistentFlags().GetBool("no-confirm"); noConfirm {
+		return nil
+	}
+	if !beautiful.IsInteractive() {
+		return errors.New(manager.T(requiredKey))
+	}
+	confirmed, err := beautiful.AskConfirm(question)
+	if err != nil && !errors.Is(err, beautiful.ErrCancelled) {
+		return err
+	}
+	if !confirmed {
+		return errors.New(manager.T(cancelledKey))
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package cmdutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	crashIssueURL      = "https://github.com/geffersonFerraz/cli/issues/new"
+	crashIssueMaxStack = 4000
+)
+
+// CrashReport descreve uma falha inesperada da CLI, já sem valores sensíveis
+type CrashReport struct {
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Version string    `json:"version"`
+	OS      string    `json:"os"`
+	Arch    string    `json:"arch"`
+	Args    []string  `json:"args"`
+	Error   string    `json:"error"`
+	Stack   string    `json:"stack"`
+}
+
+// NewCrashReport monta um relatório de falha aplicando o Redactor aos
+// argumentos, à mensagem de erro e ao stack trace
+func NewCrashReport(redactor *Redactor, version string, args []string, recovered any, stack []byte) CrashReport {
+	now := time.Now()
+	redactedArgs := redactor.Args(args)
+
+	return CrashReport{
+		ID:      now.Format("20060102-150405.000000"),
+		Time:    now,
+		Version: version,
+		OS:      runtime.GOOS,
+		Arch:    runtime.GOARCH,
+		Args:    redactedArgs,
+		Error:   redactor.Text(fmt.Sprint(recovered)),
+		Stack:   redactor.Text(string(stack)),
+	}
+}
+
+// CommandLine retorna os argumentos do relatório como uma única linha
+func (r CrashReport) CommandLine() string {
+	return strings.Join(r.Args, " ")
+}
+
+// IssueURL monta o link para abrir uma issue já preenchida com o relatório
+func (r CrashReport) IssueURL() string {
+	stack := r.Stack
+	if len(stack) > crashIssueMaxStack {
+		stack = stack[:crashIssueMaxStack] + "\n..."
+	}
+
+	query := url.Values{}
+	query.Add("title", fmt.Sprintf("Error report at '%s'", r.CommandLine()))
+	query.Add("body", fmt.Sprintf("Version: %s\nSO: %s / %s\nArgs: %s\nError: %s\n\nStack:\n```\n%s\n```\n",
+		r.Version,
+		r.OS,
+		r.Arch,
+		r.CommandLine(),
+		r.Error,
+		stack))
+	return crashIssueURL + "?" + query.Encode()
+}
+
+// SaveCrashReport grava o relatório no diretório de falhas e retorna o caminho do arquivo
+func SaveCrashReport(report CrashReport) (string, error) {
+	dir, err := CrashDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, report.ID+".json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ListCrashReports retorna os relatórios gravados, do mais recente para o mais antigo
+func ListCrashReports() ([]CrashReport, error) {
+	dir, err := CrashDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []CrashReport
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		report, err := LoadCrashReport(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Time.After(reports[j].Time)
+	})
+	return reports, nil
+}
+
+// LoadCrashReport lê um relatório pelo seu ID
+func LoadCrashReport(id string) (CrashReport, error) {
+	dir, err := CrashDir()
+	if err != nil {
+		return CrashReport{}, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.Base(id)+".json"))
+	if err != nil {
+		return CrashReport{}, err
+	}
+
+	var report CrashReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return CrashReport{}, err
+	}
+	return report, nil
+}
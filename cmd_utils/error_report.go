@@ -0,0 +1,66 @@
+package cmdutils
+
+import (
+	"errors"
+
+	clientSDK "github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// ErrorReport é a representação estruturada de um erro, usada com --output json
+type ErrorReport struct {
+	Message    string   `json:"message"`
+	Detail     string   `json:"detail,omitempty"`
+	ExitCode   int      `json:"exit_code"`
+	StatusCode int      `json:"status_code,omitempty"`
+	Status     string   `json:"status,omitempty"`
+	Body       any      `json:"body,omitempty"`
+	URL        string   `json:"url,omitempty"`
+	RequestID  string   `json:"request_id,omitempty"`
+	MgcTraceID string   `json:"mgc_trace_id,omitempty"`
+	Retries    int      `json:"retries,omitempty"`
+	Field      string   `json:"field,omitempty"`
+	Hints      []string `json:"hints,omitempty"`
+}
+
+// BuildErrorReport monta um ErrorReport a partir de um erro do SDK ou genérico
+func BuildErrorReport(err error) ErrorReport {
+	msg, detail := ParseSDKError(err)
+	report := ErrorReport{
+		Message:  msg,
+		Detail:   detail,
+		ExitCode: ExitCode(err),
+	}
+
+	if err == nil {
+		return report
+	}
+
+	var retryErr *clientSDK.RetryError
+	if errors.As(err, &retryErr) && retryErr != nil {
+		report.Retries = retryErr.Retries
+		if retryErr.LastError != nil {
+			err = retryErr.LastError
+		}
+	}
+
+	var httpErr *clientSDK.HTTPError
+	if errors.As(err, &httpErr) && httpErr != nil {
+		report.StatusCode = httpErr.StatusCode
+		if response, buildErr := buildFromSDKError(httpErr); buildErr == nil {
+			report.Status = response.Status
+			report.Body = response.JSONBody()
+			report.URL = response.URL
+			report.RequestID = response.RequestID
+			report.MgcTraceID = response.MgcTraceID
+		}
+		report.Detail = ""
+	}
+
+	var validationErr *clientSDK.ValidationError
+	if errors.As(err, &validationErr) && validationErr != nil {
+		report.Field = validationErr.Field
+		report.Detail = validationErr.Message
+	}
+
+	return report
+}
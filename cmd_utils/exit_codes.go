@@ -0,0 +1,78 @@
+package cmdutils
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	clientSDK "github.com/MagaluCloud/mgc-sdk-go/client"
+)
+
+// Códigos de saída da CLI. Scripts podem usar esses valores para decidir
+// como reagir a uma falha sem precisar interpretar a mensagem de erro.
+const (
+	ExitOK         = 0 // Sucesso
+	ExitGeneric    = 1 // Erro inesperado ou não classificado
+	ExitAuth       = 3 // Falha de autenticação ou autorização (HTTP 401/403)
+	ExitNotFound   = 4 // Recurso não encontrado (HTTP 404)
+	ExitConflict   = 5 // Conflito de estado do recurso (HTTP 409)
+	ExitValidation = 6 // Requisição inválida (HTTP 400/422 ou validação do SDK)
+	ExitRateLimit  = 7 // Limite de requisições atingido (HTTP 429)
+	ExitServer     = 8 // Erro no servidor (HTTP 5xx)
+	ExitTimeout    = 9 // Tempo esgotado (HTTP 408/504 ou timeout de rede)
+)
+
+// ExitCode mapeia um erro para o código de saída correspondente
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var retryErr *clientSDK.RetryError
+	if errors.As(err, &retryErr) && retryErr != nil && retryErr.LastError != nil {
+		return ExitCode(retryErr.LastError)
+	}
+
+	var httpErr *clientSDK.HTTPError
+	if errors.As(err, &httpErr) && httpErr != nil {
+		return exitCodeFromStatus(httpErr.StatusCode)
+	}
+
+	var validationErr *clientSDK.ValidationError
+	if errors.As(err, &validationErr) {
+		return ExitValidation
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ExitTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ExitTimeout
+	}
+
+	return ExitGeneric
+}
+
+// exitCodeFromStatus mapeia um status HTTP para o código de saída
+func exitCodeFromStatus(status int) int {
+	switch {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return ExitAuth
+	case status == http.StatusNotFound:
+		return ExitNotFound
+	case status == http.StatusConflict:
+		return ExitConflict
+	case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+		return ExitValidation
+	case status == http.StatusTooManyRequests:
+		return ExitRateLimit
+	case status == http.StatusRequestTimeout, status == http.StatusGatewayTimeout:
+		return ExitTimeout
+	case status >= 500:
+		return ExitServer
+	}
+	return ExitGeneric
+}
@@ -0,0 +1,41 @@
+package cmdutils
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WriteFileAtomic grava data com a permissão perm. O arquivo é escrito ao
+// lado do destino e renomeado, para não deixar um arquivo pela metade em
+// caso de erro.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// WriteSecret grava o segredo em um arquivo legível só pelo usuário,
+// inclusive quando o arquivo já existe com outra permissão
+func WriteSecret(path, secret string) error {
+	return WriteFileAtomic(path, []byte(secret+"\n"), 0600)
+}
@@ -0,0 +1,177 @@
+package cmdutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gfcli/i18n"
+
+	clientSDK "github.com/MagaluCloud/mgc-sdk-go/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Hints analisa um erro e retorna dicas traduzidas sobre como corrigi-lo,
+// apontando para as flags do comando envolvidas sempre que possível
+func Hints(cmd *cobra.Command, err error) []string {
+	if err == nil {
+		return nil
+	}
+	manager := i18n.GetInstance()
+
+	var validationErr *clientSDK.ValidationError
+	if errors.As(err, &validationErr) && validationErr != nil {
+		return []string{fieldHint(manager, cmd, validationErr.Field, validationErr.Message)}
+	}
+
+	var retryErr *clientSDK.RetryError
+	if errors.As(err, &retryErr) && retryErr != nil && retryErr.LastError != nil {
+		err = retryErr.LastError
+	}
+
+	var httpErr *clientSDK.HTTPError
+	if !errors.As(err, &httpErr) || httpErr == nil {
+		if ExitCode(err) == ExitTimeout {
+			return []string{manager.T("hints.timeout")}
+		}
+		return nil
+	}
+
+	body := strings.ToLower(string(httpErr.Body))
+	if strings.Contains(body, "quota") {
+		return []string{manager.T("hints.quota_exceeded")}
+	}
+
+	switch httpErr.StatusCode {
+	case http.StatusUnauthorized:
+		return []string{manager.T("hints.unauthorized", "--api-key", "CLI_API_KEY")}
+	case http.StatusForbidden:
+		return []string{manager.T("hints.forbidden", cmd.CommandPath())}
+	case http.StatusNotFound:
+		idFlags := changedIDFlags(cmd)
+		if len(idFlags) == 0 {
+			return []string{manager.T("hints.not_found")}
+		}
+		return []string{manager.T("hints.not_found_flags", strings.Join(idFlags, ", "))}
+	case http.StatusConflict:
+		return []string{manager.T("hints.conflict")}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		var hints []string
+		for _, field := range bodyFields(httpErr.Body) {
+			hints = append(hints, fieldHint(manager, cmd, field.name, field.message))
+		}
+		if len(hints) == 0 {
+			hints = append(hints, manager.T("hints.validation"))
+		}
+		return hints
+	case http.StatusTooManyRequests:
+		return []string{manager.T("hints.rate_limit")}
+	}
+
+	if httpErr.StatusCode >= 500 {
+		return []string{manager.T("hints.server_error")}
+	}
+	return nil
+}
+
+// fieldHint monta a dica de um campo inválido, indicando a flag correspondente
+func fieldHint(manager *i18n.Manager, cmd *cobra.Command, field, message string) string {
+	if flag := flagForField(cmd, field); flag != "" {
+		return manager.T("hints.invalid_flag", flag, message)
+	}
+	return manager.T("hints.invalid_field", field, message)
+}
+
+// changedIDFlags retorna as flags de ID informadas pelo usuário
+func changedIDFlags(cmd *cobra.Command) []string {
+	var names []string
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if flag.Name == "id" || strings.HasSuffix(flag.Name, "-id") || strings.HasSuffix(flag.Name, ".id") {
+			names = append(names, "--"+flag.Name)
+		}
+	})
+	sort.Strings(names)
+	return names
+}
+
+// flagForField encontra a flag do comando que corresponde a um campo da API.
+// A comparação ignora separadores, então "cidr_block" casa com "--c-id-r-block"
+// e "machine_type.id" casa com "--machine-type.id".
+func flagForField(cmd *cobra.Command, field string) string {
+	if cmd == nil || field == "" {
+		return ""
+	}
+
+	target := normalizeFieldName(field)
+	var found string
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if found == "" && normalizeFieldName(flag.Name) == target {
+			found = "--" + flag.Name
+		}
+	})
+	return found
+}
+
+func normalizeFieldName(name string) string {
+	replacer := strings.NewReplacer("-", "", "_", "", ".", "")
+	return strings.ToLower(replacer.Replace(name))
+}
+
+type bodyField struct {
+	name    string
+	message string
+}
+
+// bodyFields extrai os campos inválidos do corpo de uma resposta de erro.
+// Suporta os formatos {"detail": [{"loc": [...], "msg": ...}]} e
+// {"errors": [{"field": ..., "message": ...}]}.
+func bodyFields(body []byte) []bodyField {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+
+	var fields []bodyField
+	for _, key := range []string{"detail", "details", "errors"} {
+		items, ok := payload[key].([]any)
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			obj, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			field := bodyField{
+				name:    firstString(obj, "field", "parameter", "name"),
+				message: firstString(obj, "msg", "message", "detail"),
+			}
+			if loc, ok := obj["loc"].([]any); ok {
+				var parts []string
+				for _, part := range loc {
+					if s := fmt.Sprint(part); s != "body" && s != "query" && s != "path" {
+						parts = append(parts, s)
+					}
+				}
+				field.name = strings.Join(parts, ".")
+			}
+			if field.name != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+func firstString(obj map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := obj[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
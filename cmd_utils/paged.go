@@ -0,0 +1,27 @@
+package cmdutils
+
+import "reflect"
+
+// PageSize é o tamanho das páginas pedidas por Paged
+const PageSize = 50
+
+// Paged percorre as páginas de uma listagem com limit e offset
+func Paged[T any](fetch func(limit, offset *int) ([]T, error)) ([]T, error) {
+	var items []T
+	for offset := 0; ; offset += PageSize {
+		limit, offset := PageSize, offset
+		page, err := fetch(&limit, &offset)
+		if err != nil {
+			return nil, err
+		}
+		// Uma listagem que ignora o offset devolve a primeira página de novo
+		if offset > 0 && len(page) > 0 && reflect.DeepEqual(page[0], items[0]) {
+			return items, nil
+		}
+		items = append(items, page...)
+		// Listagens que ignoram o limit voltam com tudo de uma vez
+		if len(page) != PageSize {
+			return items, nil
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package cmdutils
+
+import (
+	"errors"
+	"testing"
+)
+
+// listing simula uma listagem de total itens. Com ignoreOffset toda página
+// começa do início, e com ignoreLimit a primeira página traz tudo.
+func listing(total int, ignoreOffset, ignoreLimit bool, calls *int) func(limit, offset *int) ([]int, error) {
+	return func(limit, offset *int) ([]int, error) {
+		*calls++
+		if *calls > 100 {
+			return nil, errors.New("too many calls")
+		}
+		start, end := *offset, *offset+*limit
+		if ignoreOffset {
+			start, end = 0, *limit
+		}
+		if ignoreLimit {
+			end = total
+		}
+		var page []int
+		for i := start; i < end && i < total; i++ {
+			page = append(page, i)
+		}
+		return page, nil
+	}
+}
+
+func TestPaged(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        int
+		ignoreOffset bool
+		ignoreLimit  bool
+		want         int
+		wantCalls    int
+	}{
+		{"empty", 0, false, false, 0, 1},
+		{"short page", 20, false, false, 20, 1},
+		{"full pages", 2 * PageSize, false, false, 2 * PageSize, 3},
+		{"last page short", 2*PageSize + 1, false, false, 2*PageSize + 1, 3},
+		{"offset ignored", 3 * PageSize, true, false, PageSize, 2},
+		{"limit ignored", 3 * PageSize, false, true, 3 * PageSize, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			items, err := Paged(listing(tt.total, tt.ignoreOffset, tt.ignoreLimit, &calls))
+			if err != nil {
+				t.Fatalf("Paged: %v", err)
+			}
+			if len(items) != tt.want || calls != tt.wantCalls {
+				t.Errorf("got %d items in %d calls, want %d in %d", len(items), calls, tt.want, tt.wantCalls)
+			}
+			for i, item := range items {
+				if item != i {
+					t.Fatalf("item %d = %d", i, item)
+				}
+			}
+		})
+	}
+}
+
+func TestPagedError(t *testing.T) {
+	failure := errors.New("boom")
+	items, err := Paged(func(limit, offset *int) ([]int, error) {
+		if *offset > 0 {
+			return nil, failure
+		}
+		return make([]int, PageSize), nil
+	})
+	if !errors.Is(err, failure) || items != nil {
+		t.Errorf("Paged() = %d items, %v; want the fetch error", len(items), err)
+	}
+}
@@ -0,0 +1,39 @@
+package cmdutils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const appDirName = "gfcli"
+
+// ConfigDir retorna o diretório de configuração do usuário para a CLI
+func ConfigDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, appDirName), nil
+}
+
+// CrashDir retorna o diretório onde os relatórios de falha são gravados
+func CrashDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, appDirName, "crashes"), nil
+}
+
+// ExpandHome troca o ~ do início do caminho pelo diretório do usuário
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,185 @@
+package cmdutils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"hash"
+
+	"gfcli/i18n"
+)
+
+// ErrWrongPassphrase indica que a chave privada não pôde ser decifrada com a senha informada
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// Identificadores usados pelas chaves PKCS#8 cifradas com PBES2
+var (
+	oidPBES2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidDESEDE3   = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+
+	prfs = map[string]func() hash.Hash{
+		"1.2.840.113549.2.7":  sha1.New,
+		"1.2.840.113549.2.8":  sha256.New224,
+		"1.2.840.113549.2.9":  sha256.New,
+		"1.2.840.113549.2.10": sha512.New384,
+		"1.2.840.113549.2.11": sha512.New,
+	}
+)
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Data      []byte
+}
+
+type pbes2Params struct {
+	KeyDerivation pkix.AlgorithmIdentifier
+	Encryption    pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt       []byte
+	Iterations int
+	KeyLength  int                      `asn1:"optional"`
+	PRF        pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// ParsePrivateKey lê uma chave privada PEM nos formatos PKCS#1, PKCS#8 ou SEC 1.
+// Para chaves cifradas, passphrase é chamada para obter a senha; chaves
+// sem cifra não a chamam.
+func ParsePrivateKey(data []byte, passphrase func() ([]byte, error)) (any, error) {
+	manager := i18n.GetInstance()
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New(manager.T("cli.private_key.not_pem"))
+	}
+
+	der := block.Bytes
+	encrypted := true
+	switch {
+	case block.Type == "OPENSSH PRIVATE KEY":
+		return nil, errors.New(manager.T("cli.private_key.openssh"))
+	case x509.IsEncryptedPEMBlock(block):
+		// Cifra legada do PEM, depreciada no Go mas ainda gerada pelo openssl
+		password, err := passphrase()
+		if err != nil {
+			return nil, err
+		}
+		if der, err = x509.DecryptPEMBlock(block, password); err != nil {
+			return nil, ErrWrongPassphrase
+		}
+	case block.Type == "ENCRYPTED PRIVATE KEY":
+		password, err := passphrase()
+		if err != nil {
+			return nil, err
+		}
+		if der, err = decryptPKCS8(block.Bytes, password); err != nil {
+			return nil, err
+		}
+	default:
+		encrypted = false
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(der)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(der)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(der)
+	}
+	if err != nil {
+		if encrypted {
+			// Com a senha errada o padding pode sair válido por acaso
+			return nil, ErrWrongPassphrase
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// decryptPKCS8 decifra um EncryptedPrivateKeyInfo com PBES2 (RFC 8018)
+func decryptPKCS8(data, password []byte) ([]byte, error) {
+	unsupported := errors.New(i18n.GetInstance().T("cli.private_key.unsupported_cipher"))
+
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, unsupported
+	}
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, err
+	}
+	if !params.KeyDerivation.Algorithm.Equal(oidPBKDF2) {
+		return nil, unsupported
+	}
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivation.Parameters.FullBytes, &kdf); err != nil {
+		return nil, err
+	}
+	prf := sha1.New
+	if len(kdf.PRF.Algorithm) > 0 {
+		if prf = prfs[kdf.PRF.Algorithm.String()]; prf == nil {
+			return nil, unsupported
+		}
+	}
+
+	var newCipher func(key []byte) (cipher.Block, error)
+	var keyLength int
+	switch encryption := params.Encryption.Algorithm; {
+	case encryption.Equal(oidAES128CBC):
+		newCipher, keyLength = aes.NewCipher, 16
+	case encryption.Equal(oidAES192CBC):
+		newCipher, keyLength = aes.NewCipher, 24
+	case encryption.Equal(oidAES256CBC):
+		newCipher, keyLength = aes.NewCipher, 32
+	case encryption.Equal(oidDESEDE3):
+		newCipher, keyLength = des.NewTripleDESCipher, 24
+	default:
+		return nil, unsupported
+	}
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.Encryption.Parameters.FullBytes, &iv); err != nil {
+		return nil, err
+	}
+
+	key, err := pbkdf2.Key(prf, string(password), kdf.Salt, kdf.Iterations, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != block.BlockSize() || len(info.Data) == 0 || len(info.Data)%block.BlockSize() != 0 {
+		return nil, unsupported
+	}
+
+	plain := make([]byte, len(info.Data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, info.Data)
+
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > block.BlockSize() || !bytes.Equal(plain[len(plain)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, ErrWrongPassphrase
+	}
+	return plain[:len(plain)-padding], nil
+}
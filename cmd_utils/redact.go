@@ -0,0 +1,140 @@
+package cmdutils
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Redacted substitui valores sensíveis em relatórios e logs
+const Redacted = "[REDACTED]"
+
+// minTextSecret é o tamanho mínimo de um segredo para que ele seja ocultado
+// em textos livres. Valores curtos, como um --key=1, apareceriam por acaso em
+// qualquer stack trace; nos argumentos eles continuam sempre ocultados.
+const minTextSecret = 8
+
+// SecretFlags lista as flags cujo valor nunca deve sair da máquina do usuário
+var SecretFlags = []string{
+	"api-key",
+	"password",
+	"key",
+	"private-key",
+	"passphrase",
+}
+
+// SecretEnvVars lista as variáveis de ambiente cujo valor é sensível
+var SecretEnvVars = []string{
+	"CLI_API_KEY",
+}
+
+// Redactor remove valores sensíveis de argumentos e textos livres
+type Redactor struct {
+	names      map[string]bool
+	shorthands map[string]bool
+	secrets    []string
+}
+
+// NewRedactor cria um Redactor. Quando root é informado, os atalhos das
+// flags sensíveis do comando executado também são reconhecidos.
+func NewRedactor(root *cobra.Command, args []string) *Redactor {
+	r := &Redactor{
+		names:      make(map[string]bool),
+		shorthands: make(map[string]bool),
+	}
+	for _, name := range SecretFlags {
+		r.names[name] = true
+	}
+	for _, env := range SecretEnvVars {
+		if value := os.Getenv(env); value != "" {
+			r.secrets = append(r.secrets, value)
+		}
+	}
+
+	if root != nil && len(args) > 1 {
+		if cmd, _, err := root.Find(args[1:]); err == nil && cmd != nil {
+			collect := func(flag *pflag.Flag) {
+				if r.names[flag.Name] && flag.Shorthand != "" {
+					r.shorthands[flag.Shorthand] = true
+				}
+			}
+			cmd.Flags().VisitAll(collect)
+			cmd.InheritedFlags().VisitAll(collect)
+		}
+	}
+	return r
+}
+
+// Args retorna uma cópia dos argumentos com os valores sensíveis ocultados
+func (r *Redactor) Args(args []string) []string {
+	result := make([]string, len(args))
+	copy(result, args)
+
+	for i := 0; i < len(result); i++ {
+		arg := result[i]
+		if arg == "--" {
+			break
+		}
+
+		name, value, hasValue, isShort := splitFlag(arg)
+		if name == "" || !r.isSecret(name, isShort) {
+			continue
+		}
+
+		if hasValue {
+			r.remember(value)
+			result[i] = strings.TrimSuffix(arg, value) + Redacted
+			continue
+		}
+		if i+1 < len(result) {
+			r.remember(result[i+1])
+			result[i+1] = Redacted
+			i++
+		}
+	}
+	return result
+}
+
+// Text oculta em um texto livre os valores sensíveis já conhecidos com pelo
+// menos minTextSecret caracteres
+func (r *Redactor) Text(text string) string {
+	for _, secret := range r.secrets {
+		if len(secret) < minTextSecret {
+			continue
+		}
+		text = strings.ReplaceAll(text, secret, Redacted)
+	}
+	return text
+}
+
+func (r *Redactor) isSecret(name string, isShort bool) bool {
+	if isShort {
+		return r.shorthands[name]
+	}
+	return r.names[name]
+}
+
+func (r *Redactor) remember(value string) {
+	if value != "" && value != Redacted {
+		r.secrets = append(r.secrets, value)
+	}
+}
+
+// splitFlag separa um argumento nos formatos --nome, --nome=valor, -n, -n=valor e -nvalor
+func splitFlag(arg string) (name, value string, hasValue, isShort bool) {
+	switch {
+	case strings.HasPrefix(arg, "--"):
+		name = strings.TrimPrefix(arg, "--")
+		if idx := strings.Index(name, "="); idx >= 0 {
+			return name[:idx], name[idx+1:], true, false
+		}
+		return name, "", false, false
+	case strings.HasPrefix(arg, "-") && len(arg) > 1:
+		name = arg[1:2]
+		rest := strings.TrimPrefix(arg[2:], "=")
+		return name, rest, rest != "", true
+	}
+	return "", "", false, false
+}
@@ -1,17 +1,21 @@
 package cmdutils
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	"gfcli/i18n"
+
 	clientSDK "github.com/MagaluCloud/mgc-sdk-go/client"
 )
 
 const (
-	simpleHttpError       = "API request failed with HTTP error"
-	simpleValidationError = "Request validation failed"
-	simpleGenericError    = "An unexpected error occurred"
-	simpleMaxRetriesError = "Max HTTP retries exceeded"
+	// Chaves de tradução das mensagens resumidas de erro
+	simpleHttpError       = "cli.error.http"
+	simpleValidationError = "cli.error.validation"
+	simpleGenericError    = "cli.error.generic"
+	simpleMaxRetriesError = "cli.error.max_retries"
 
 	MgcTraceIDKey = "x-mgc-trace-id"
 )
@@ -38,6 +42,17 @@ func (e HttpErrorResponse) Error() string {
 	return e.String()
 }
 
+// JSONBody retorna o corpo como JSON quando válido, ou como string caso contrário
+func (e HttpErrorResponse) JSONBody() any {
+	if e.Body == "" {
+		return nil
+	}
+	if json.Valid([]byte(e.Body)) {
+		return json.RawMessage(e.Body)
+	}
+	return e.Body
+}
+
 func buildFromSDKError(err *clientSDK.HTTPError) (HttpErrorResponse, error) {
 	if err == nil {
 		return HttpErrorResponse{}, fmt.Errorf("cannot build error response from nil error")
@@ -64,41 +79,42 @@ func buildFromSDKError(err *clientSDK.HTTPError) (HttpErrorResponse, error) {
 }
 
 func ParseSDKError(err error) (msg, detail string) {
+	manager := i18n.GetInstance()
 	if err == nil {
-		return simpleGenericError, "nil error provided"
+		return manager.T(simpleGenericError), "nil error provided"
 	}
 
 	switch e := err.(type) {
 	case *clientSDK.HTTPError:
 		errorResponse, buildErr := buildFromSDKError(e)
 		if buildErr != nil {
-			return simpleGenericError, buildErr.Error()
+			return manager.T(simpleGenericError), buildErr.Error()
 		}
-		return simpleHttpError, errorResponse.String()
+		return manager.T(simpleHttpError), errorResponse.String()
 
 	case *clientSDK.ValidationError:
 		if e == nil {
-			return simpleValidationError, "nil validation error"
+			return manager.T(simpleValidationError), "nil validation error"
 		}
-		return simpleValidationError, fmt.Sprintf("Field: %s - Message: %s", e.Field, e.Message)
+		return manager.T(simpleValidationError), manager.T("cli.error.validation_detail", e.Field, e.Message)
 
 	case *clientSDK.RetryError:
 		if e == nil {
-			return simpleMaxRetriesError, ""
+			return manager.T(simpleMaxRetriesError), ""
 		}
 		if e.LastError == nil {
-			return simpleMaxRetriesError, "unexpected last retry error"
+			return manager.T(simpleMaxRetriesError), "unexpected last retry error"
 		}
 		if he, ok := e.LastError.(*clientSDK.HTTPError); ok {
 			errorResponse, buildErr := buildFromSDKError(he)
 			if buildErr != nil {
-				return simpleMaxRetriesError, buildErr.Error()
+				return manager.T(simpleMaxRetriesError), buildErr.Error()
 			}
-			return simpleMaxRetriesError, fmt.Sprintf("Max HTTP retries exceeded at %d retries.\nLast error:\n %s", e.Retries, errorResponse.String())
+			return manager.T(simpleMaxRetriesError), manager.T("cli.error.retries_detail", e.Retries, "\n "+errorResponse.String())
 		}
-		return simpleMaxRetriesError, fmt.Sprintf("Max HTTP retries exceeded at %d retries.\nLast error: %s", e.Retries, e.LastError.Error())
+		return manager.T(simpleMaxRetriesError), manager.T("cli.error.retries_detail", e.Retries, e.LastError.Error())
 
 	default:
-		return simpleGenericError, err.Error()
+		return manager.T(simpleGenericError), err.Error()
 	}
 }
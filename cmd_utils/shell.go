@@ -0,0 +1,23 @@
+package cmdutils
+
+import "strings"
+
+// shellSafe são os caracteres que o shell não interpreta e dispensam aspas
+const shellSafe = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%+=:,./_-"
+
+// ShellQuote protege o valor com aspas simples quando o shell exigiria
+func ShellQuote(value string) string {
+	if value != "" && strings.Trim(value, shellSafe) == "" {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// ShellJoin junta os argumentos em uma linha de comando pronta para o shell
+func ShellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = ShellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,84 @@
+package cmdutils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ConfigLanguage é a chave da configuração que guarda o idioma escolhido
+const ConfigLanguage = "lang"
+
+const userConfigFile = "config.json"
+
+// UserConfig guarda as preferências persistidas do usuário
+type UserConfig map[string]string
+
+// UserConfigPath retorna o caminho do arquivo de configuração do usuário
+func UserConfigPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, userConfigFile), nil
+}
+
+// LoadUserConfig lê a configuração do usuário. Um arquivo inexistente
+// resulta em uma configuração vazia.
+func LoadUserConfig() (UserConfig, error) {
+	path, err := UserConfigPath()
+	if err != nil {
+		return UserConfig{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return UserConfig{}, nil
+	}
+	if err != nil {
+		return UserConfig{}, err
+	}
+
+	config := UserConfig{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return UserConfig{}, err
+	}
+	return config, nil
+}
+
+// Save grava a configuração no diretório de configuração do usuário
+func (c UserConfig) Save() error {
+	path, err := UserConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Keys retorna as chaves da configuração em ordem alfabética
+func (c UserConfig) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SavedLanguage retorna o idioma persistido com 'cli i18n set', se houver
+func SavedLanguage() string {
+	config, err := LoadUserConfig()
+	if err != nil {
+		return ""
+	}
+	return config[ConfigLanguage]
+}
@@ -0,0 +1,121 @@
+package cmdutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrEmptyDocument indica um documento sem nenhum conteúdo, só espaços ou comentários
+var ErrEmptyDocument = errors.New("empty document")
+
+// DocumentToJSON aceita um documento JSON ou YAML e retorna sua forma JSON
+func DocumentToJSON(data []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if json.Valid(trimmed) {
+		return trimmed, nil
+	}
+	return YAMLToJSON(data)
+}
+
+// YAMLToJSON converte um documento YAML em JSON preservando a ordem das chaves
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		return nil, ErrEmptyDocument
+	}
+
+	var buf bytes.Buffer
+	if err := writeYAMLNode(&buf, &doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeYAMLNode escreve um nó YAML como JSON
+func writeYAMLNode(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeYAMLNode(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeYAMLNode(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeYAMLNode(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeYAMLNode(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		return writeYAMLScalar(buf, node)
+	default:
+		return fmt.Errorf("unsupported YAML node at line %d", node.Line)
+	}
+	return nil
+}
+
+// writeYAMLScalar escreve um escalar YAML respeitando seu tipo
+func writeYAMLScalar(buf *bytes.Buffer, node *yaml.Node) error {
+	var value any = node.Value
+
+	switch node.ShortTag() {
+	case "!!null":
+		value = nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return err
+		}
+		value = b
+	case "!!int":
+		var i int64
+		if err := node.Decode(&i); err != nil {
+			return err
+		}
+		value = i
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err != nil {
+			return err
+		}
+		if !math.IsNaN(f) && !math.IsInf(f, 0) {
+			value = f
+		}
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(encoded)
+	return nil
+}
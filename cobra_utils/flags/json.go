@@ -27,6 +27,9 @@ func (j *JSONValue[T]) Set(val string) error {
 
 // String serializa o valor atual para JSON
 func (j *JSONValue[T]) String() string {
+	if j.Value == nil {
+		return "{}"
+	}
 	b, err := json.Marshal(*j.Value)
 	if err != nil {
 		return "{}"
@@ -40,13 +43,13 @@ func (j *JSONValue[T]) Type() string {
 }
 
 func NewJSONValue[T any](cmd *cobra.Command, name string, usage string) *JSONValue[T] {
-	var value *JSONValue[T] = new(JSONValue[T])
+	var value *JSONValue[T] = &JSONValue[T]{Value: new(T)}
 	cmd.Flags().Var(value, name, usage)
 	return &JSONValue[T]{baseFlag: baseFlag{cmd, name}, Value: value.Value}
 }
 
 func NewJSONValueP[T any](cmd *cobra.Command, name string, shorthand string, usage string) *JSONValue[T] {
-	var value *JSONValue[T] = new(JSONValue[T])
+	var value *JSONValue[T] = &JSONValue[T]{Value: new(T)}
 	cmd.Flags().VarP(value, name, shorthand, usage)
 	return &JSONValue[T]{baseFlag: baseFlag{cmd, name}, Value: value.Value}
 }
@@ -31,7 +31,9 @@ func (j *JSONArrayValue[T]) Set(val string) error {
 
 // String serializa o valor atual para JSON
 func (j *JSONArrayValue[T]) String() string {
-	j.Value = new([]T)
+	if j.Value == nil || *j.Value == nil {
+		return "[]"
+	}
 	b, err := json.Marshal(*j.Value)
 	if err != nil || len(b) == 0 {
 		return "[]"
@@ -45,13 +47,13 @@ func (j *JSONArrayValue[T]) Type() string {
 }
 
 func NewJSONArrayValue[T any](cmd *cobra.Command, name string, usage string) *JSONArrayValue[T] {
-	var value *JSONArrayValue[T] = new(JSONArrayValue[T])
+	var value *JSONArrayValue[T] = &JSONArrayValue[T]{Value: new([]T)}
 	cmd.Flags().Var(value, name, usage)
 	return &JSONArrayValue[T]{baseFlag: baseFlag{cmd, name}, Value: value.Value}
 }
 
 func NewJSONArrayValueP[T any](cmd *cobra.Command, name string, shorthand string, usage string) *JSONArrayValue[T] {
-	var value *JSONArrayValue[T] = new(JSONArrayValue[T])
+	var value *JSONArrayValue[T] = &JSONArrayValue[T]{Value: new([]T)}
 	cmd.Flags().VarP(value, name, shorthand, usage)
 	return &JSONArrayValue[T]{baseFlag: baseFlag{cmd, name}, Value: value.Value}
 }
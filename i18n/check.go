@@ -0,0 +1,267 @@
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// placeholderPattern reconhece os verbos de formatação do pacote fmt
+var placeholderPattern = regexp.MustCompile(`%[-+# 0]*(\[\d+\])?(\d+|\*)?(\.(\d+|\*))?(\[\d+\])?[a-zA-Z%]`)
+
+// argIndexPattern reconhece o índice explícito de argumento, como em %[2]s
+var argIndexPattern = regexp.MustCompile(`\[\d+\]`)
+
+// keyLiteralPattern reconhece literais de string com o formato de uma chave
+// de tradução. Um literal terminado em ponto é um prefixo de chaves montadas
+// em tempo de execução, como "cli.ui.column." + key.
+var keyLiteralPattern = regexp.MustCompile(`"((?:[a-z0-9_-]+\.)+[a-z0-9_-]*)"`)
+
+// PlaceholderMismatch descreve uma tradução cujos verbos de formatação
+// diferem dos usados no idioma de referência
+type PlaceholderMismatch struct {
+	Key         string
+	Reference   []string
+	Translation []string
+}
+
+// LocaleReport é o resultado da verificação de um idioma
+type LocaleReport struct {
+	Code         string
+	Missing      []string
+	Empty        []string
+	Unused       []string
+	Placeholders []PlaceholderMismatch
+}
+
+// Problems retorna a quantidade de problemas encontrados no idioma
+func (r LocaleReport) Problems() int {
+	return len(r.Missing) + len(r.Empty) + len(r.Unused) + len(r.Placeholders)
+}
+
+// KeyUsage descreve as chaves usadas pela CLI: as consultadas pelo T e as
+// encontradas no código-fonte, além dos prefixos de chaves dinâmicas
+type KeyUsage struct {
+	Keys     map[string]bool
+	Prefixes []string
+	// Complete indica que o uso veio de uma varredura do código-fonte e
+	// portanto pode ser usado para apontar chaves sem uso
+	Complete bool
+}
+
+// uses verifica se a chave é usada diretamente, como forma de plural de uma
+// chave usada ou por um prefixo
+func (u KeyUsage) uses(key string) bool {
+	if base, plural := pluralBase(key); plural && u.Keys[base] {
+		return true
+	}
+	if u.Keys[key] {
+		return true
+	}
+	for _, prefix := range u.Prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Placeholders retorna os verbos de formatação e os placeholders {nome} de
+// um texto, na ordem em que aparecem
+func Placeholders(text string) []string {
+	var verbs []string
+	for _, verb := range placeholderPattern.FindAllString(text, -1) {
+		if verb != "%%" {
+			verbs = append(verbs, verb)
+		}
+	}
+	return append(verbs, namedPlaceholders(text)...)
+}
+
+// sameVerbs compara os verbos ignorando índices explícitos e ordem, já que
+// uma tradução pode reordenar os argumentos com %[n]s
+func sameVerbs(a, b []string) bool {
+	normalize := func(verbs []string) []string {
+		result := make([]string, len(verbs))
+		for i, verb := range verbs {
+			result[i] = argIndexPattern.ReplaceAllString(verb, "")
+		}
+		sort.Strings(result)
+		return result
+	}
+	return slices.Equal(normalize(a), normalize(b))
+}
+
+// optionalPluralForm indica se a chave é uma forma de plural diferente de
+// "other" de uma mensagem com plural
+func optionalPluralForm(key string, universe map[string]bool) bool {
+	base, plural := pluralBase(key)
+	return plural && !strings.HasSuffix(key, "."+PluralOther) && universe[base+"."+PluralOther]
+}
+
+// withoutCount remove o placeholder {count} preenchido pelo TN
+func withoutCount(verbs []string) []string {
+	return slices.DeleteFunc(slices.Clone(verbs), func(verb string) bool { return verb == "{count}" })
+}
+
+// ScanSourceKeys procura chaves de tradução nos arquivos .go de um diretório
+func ScanSourceKeys(dir string) (KeyUsage, error) {
+	usage := KeyUsage{Keys: make(map[string]bool), Complete: true}
+	prefixes := make(map[string]bool)
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if name := entry.Name(); name == "vendor" || (strings.HasPrefix(name, ".") && path != dir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for _, match := range keyLiteralPattern.FindAllStringSubmatch(string(data), -1) {
+			if strings.HasSuffix(match[1], ".") {
+				prefixes[match[1]] = true
+			} else {
+				usage.Keys[match[1]] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return KeyUsage{}, err
+	}
+
+	for prefix := range prefixes {
+		usage.Prefixes = append(usage.Prefixes, prefix)
+	}
+	sort.Strings(usage.Prefixes)
+	return usage, nil
+}
+
+// Check compara os idiomas carregados com o idioma de referência e com as
+// chaves usadas pela CLI
+func (m *Manager) Check(reference string, usage KeyUsage) ([]LocaleReport, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	ref, exists := m.locales[reference]
+	if !exists {
+		return nil, fmt.Errorf(m.translate("i18n.language_not_found"), reference)
+	}
+
+	// O universo de chaves reúne todos os idiomas e as chaves consultadas
+	// pelo T, que podem não existir em nenhum idioma
+	universe := make(map[string]bool)
+	for _, locale := range m.locales {
+		for key := range locale.Translations {
+			universe[key] = true
+		}
+	}
+	m.used.Range(func(key, _ any) bool {
+		// Chaves consultadas pelo TN existem apenas nas formas de plural
+		if !universe[key.(string)+"."+PluralOther] {
+			universe[key.(string)] = true
+		}
+		return true
+	})
+
+	codes := make([]string, 0, len(m.locales))
+	for code := range m.locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	reports := make([]LocaleReport, 0, len(codes))
+	for _, code := range codes {
+		locale := m.locales[code]
+		report := LocaleReport{Code: code}
+
+		for key := range universe {
+			translation, exists := locale.Translations[key]
+			switch {
+			case !exists && optionalPluralForm(key, universe):
+				// Cada idioma usa só as formas de plural das suas regras
+			case !exists:
+				report.Missing = append(report.Missing, key)
+			case translation == "":
+				report.Empty = append(report.Empty, key)
+			}
+		}
+
+		for key, translation := range locale.Translations {
+			if usage.Complete && !usage.uses(key) {
+				report.Unused = append(report.Unused, key)
+			}
+			if code == reference || translation == "" {
+				continue
+			}
+			refKey := key
+			base, plural := pluralBase(key)
+			if _, exists := ref.Translations[key]; plural && !exists {
+				refKey = base + "." + PluralOther
+			}
+			refText, exists := ref.Translations[refKey]
+			if !exists || refText == "" {
+				continue
+			}
+			refVerbs, verbs := Placeholders(refText), Placeholders(translation)
+			if plural {
+				// A forma "one" costuma escrever a quantidade por extenso
+				refVerbs, verbs = withoutCount(refVerbs), withoutCount(verbs)
+			}
+			if !sameVerbs(refVerbs, verbs) {
+				report.Placeholders = append(report.Placeholders, PlaceholderMismatch{Key: key, Reference: refVerbs, Translation: verbs})
+			}
+		}
+
+		sort.Strings(report.Missing)
+		sort.Strings(report.Empty)
+		sort.Strings(report.Unused)
+		sort.Slice(report.Placeholders, func(i, j int) bool {
+			return report.Placeholders[i].Key < report.Placeholders[j].Key
+		})
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// Skeleton monta um arquivo de idioma com todas as chaves do idioma de
+// referência. Traduções já existentes para o código são mantidas e as demais
+// ficam vazias, para que o T use o idioma base ou o padrão até serem preenchidas.
+func (m *Manager) Skeleton(code, reference string) (*Locale, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	ref, exists := m.locales[reference]
+	if !exists {
+		return nil, fmt.Errorf(m.translate("i18n.language_not_found"), reference)
+	}
+
+	skeleton := &Locale{Code: code, Translations: make(map[string]string, len(ref.Translations))}
+	existing := m.locales[code]
+	if existing != nil {
+		skeleton.Name = existing.Name
+		skeleton.NativeName = existing.NativeName
+	}
+	for key := range ref.Translations {
+		skeleton.Translations[key] = ""
+		if existing != nil {
+			skeleton.Translations[key] = existing.Translations[key]
+		}
+	}
+	return skeleton, nil
+}
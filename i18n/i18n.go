@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 
@@ -20,14 +21,21 @@ type Locale struct {
 	Name         string            `json:"name"`
 	NativeName   string            `json:"native_name"`
 	Translations map[string]string `json:"translations"`
+	// Sources lista de onde as traduções vieram: EmbeddedSource e os arquivos
+	// dos diretórios de tradução que as complementam
+	Sources []string `json:"-"`
 }
 
 // Manager gerencia as traduções e idiomas
 type Manager struct {
 	locales     map[string]*Locale
 	current     *Locale
+	requested   string
+	chain       []*Locale
 	defaultLang string
 	mutex       sync.RWMutex
+	// used registra as chaves consultadas pelo T, usadas pelo 'cli i18n check'
+	used sync.Map
 }
 
 var (
@@ -45,13 +53,15 @@ func GetInstance() *Manager {
 		// Carregar traduções automaticamente na inicialização
 		if err := instance.LoadLocales(); err != nil {
 			// Log do erro, mas não falhar a inicialização
-			fmt.Printf("Warning: failed to load translations: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Warning: failed to load translations: %v\n", err)
 		}
 	})
 	return instance
 }
 
-// LoadLocales carrega os arquivos de tradução embutidos
+// LoadLocales carrega os arquivos de tradução embutidos e depois os dos
+// diretórios de tradução, que adicionam idiomas ou sobrescrevem chaves.
+// Erros nos diretórios são retornados, mas não impedem o uso das traduções.
 func (m *Manager) LoadLocales() error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -68,6 +78,7 @@ func (m *Manager) LoadLocales() error {
 			if err != nil {
 				return fmt.Errorf("erro ao carregar %s: %w", file.Name(), err)
 			}
+			locale.Sources = []string{EmbeddedSource}
 			m.locales[locale.Code] = locale
 		}
 	}
@@ -77,10 +88,13 @@ func (m *Manager) LoadLocales() error {
 		return fmt.Errorf("nenhum arquivo de tradução encontrado")
 	}
 
-	// Definir idioma atual
+	// O idioma é definido antes dos diretórios para que os erros sejam
+	// traduzidos e de novo depois, já que eles podem trazer novos idiomas
+	m.setCurrentLocale(m.detectLanguage())
+	err = m.loadDirs()
 	m.setCurrentLocale(m.detectLanguage())
 
-	return nil
+	return err
 }
 
 // loadLocaleFile carrega um arquivo de tradução individual do embed
@@ -100,60 +114,85 @@ func (m *Manager) loadLocaleFile(filepath string) (*Locale, error) {
 
 // detectLanguage detecta o idioma preferido do usuário
 func (m *Manager) detectLanguage() string {
-	// 1. Verificar variável de ambiente CLI_LANG
-	if lang := os.Getenv("CLI_LANG"); lang != "" {
-		if m.isValidLocale(lang) {
-			return lang
+	// CLI_LANG tem prioridade sobre as variáveis de localidade do sistema
+	for _, env := range []string{"CLI_LANG", "LC_ALL", "LANG"} {
+		lang := os.Getenv(env)
+		if lang == "" {
+			continue
+		}
+		// Normalizar o formato do sistema (ex: pt_BR.UTF-8 -> pt-BR)
+		code := strings.Replace(strings.Split(lang, ".")[0], "_", "-", 1)
+		if m.isValidLocale(code) {
+			return code
 		}
 	}
 
-	// 2. Verificar variável de ambiente LANG
-	if lang := os.Getenv("LANG"); lang != "" {
-		// Extrair código do idioma (ex: pt_BR.UTF-8 -> pt-BR)
-		langCode := strings.Split(lang, ".")[0]
-		langCode = strings.Replace(langCode, "_", "-", 1)
+	return m.defaultLang
+}
 
-		if m.isValidLocale(langCode) {
-			return langCode
-		}
+// baseLanguage retorna o idioma sem a região (pt-BR -> pt)
+func baseLanguage(code string) string {
+	return strings.ToLower(strings.Split(code, "-")[0])
+}
 
-		// Tentar apenas o código principal (pt_BR -> pt)
-		mainLang := strings.Split(langCode, "-")[0]
-		if m.isValidLocale(mainLang) {
-			return mainLang
+// resolve monta a cadeia de idiomas consultada pelo T: o idioma pedido, os
+// idiomas com a mesma base e por fim o idioma padrão
+func (m *Manager) resolve(code string) []*Locale {
+	var chain []*Locale
+	seen := make(map[string]bool)
+	add := func(locale *Locale) {
+		if locale != nil && !seen[locale.Code] {
+			seen[locale.Code] = true
+			chain = append(chain, locale)
 		}
 	}
 
-	// 3. Verificar variável de ambiente LC_ALL
-	if lang := os.Getenv("LC_ALL"); lang != "" {
-		langCode := strings.Split(lang, ".")[0]
-		langCode = strings.Replace(langCode, "_", "-", 1)
+	for localeCode, locale := range m.locales {
+		if strings.EqualFold(localeCode, code) {
+			add(locale)
+		}
+	}
 
-		if m.isValidLocale(langCode) {
-			return langCode
+	base := baseLanguage(code)
+	codes := make([]string, 0, len(m.locales))
+	for localeCode := range m.locales {
+		codes = append(codes, localeCode)
+	}
+	sort.Strings(codes)
+	for _, localeCode := range codes {
+		if baseLanguage(localeCode) == base {
+			add(m.locales[localeCode])
 		}
 	}
 
-	// 4. Fallback para idioma padrão
-	return m.defaultLang
+	add(m.locales[m.defaultLang])
+	return chain
 }
 
-// isValidLocale verifica se um código de idioma é válido
+// isValidLocale verifica se um código de idioma é atendido, mesmo que apenas
+// pelo idioma base (es-MX é atendido por es-ES)
 func (m *Manager) isValidLocale(code string) bool {
-	_, exists := m.locales[code]
-	return exists
+	if code == "" {
+		return false
+	}
+	chain := m.resolve(code)
+	return len(chain) > 0 && (baseLanguage(chain[0].Code) == baseLanguage(code))
 }
 
 // setCurrentLocale define o idioma atual
 func (m *Manager) setCurrentLocale(code string) {
-	if locale, exists := m.locales[code]; exists {
+	m.requested = code
+	m.chain = m.resolve(code)
+	if len(m.chain) > 0 {
+		m.current = m.chain[0]
+		return
+	}
+
+	// Fallback para o primeiro idioma disponível
+	for _, locale := range m.locales {
 		m.current = locale
-	} else {
-		// Fallback para o primeiro idioma disponível
-		for _, locale := range m.locales {
-			m.current = locale
-			break
-		}
+		m.chain = []*Locale{locale}
+		break
 	}
 }
 
@@ -163,7 +202,7 @@ func (m *Manager) SetLanguage(code string) error {
 	defer m.mutex.Unlock()
 
 	if !m.isValidLocale(code) {
-		return fmt.Errorf("idioma não suportado: %s", code)
+		return fmt.Errorf(m.translate("i18n.unsupported_language"), code)
 	}
 
 	m.setCurrentLocale(code)
@@ -181,26 +220,51 @@ func (m *Manager) GetLanguage() string {
 	return m.current.Code
 }
 
-// T traduz uma chave para o idioma atual
+// GetRequestedLanguage retorna o idioma pedido pelo usuário, que pode
+// diferir do idioma atual quando é atendido pelo idioma base
+func (m *Manager) GetRequestedLanguage() string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.requested
+}
+
+// T traduz uma chave para o idioma atual. Chaves ausentes são procuradas no
+// idioma base e depois no idioma padrão; sem tradução, a própria chave é retornada.
+// Os args seguem o fmt.Sprintf, exceto um único Args, que preenche placeholders {nome}.
 func (m *Manager) T(key string, args ...interface{}) string {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
-	if m.current == nil {
-		return key
-	}
+	m.used.Store(key, true)
+	return format(m.translate(key), args)
+}
 
-	translation, exists := m.current.Translations[key]
-	if !exists {
-		// Fallback para a chave original
-		return key
+// translate percorre a cadeia de idiomas sem obter o lock
+func (m *Manager) translate(key string) string {
+	for _, locale := range m.chain {
+		// Traduções vazias, como as de um esqueleto gerado pelo 'cli i18n extract', seguem a cadeia
+		if translation, exists := locale.Translations[key]; exists && translation != "" {
+			return translation
+		}
 	}
+	return key
+}
 
-	if len(args) > 0 {
-		return fmt.Sprintf(translation, args...)
-	}
+// UsedKeys retorna as chaves já consultadas pelo T neste processo
+func (m *Manager) UsedKeys() []string {
+	var keys []string
+	m.used.Range(func(key, _ any) bool {
+		keys = append(keys, key.(string))
+		return true
+	})
+	sort.Strings(keys)
+	return keys
+}
 
-	return translation
+// DefaultLanguage retorna o idioma usado quando nenhum outro atende a chave
+func (m *Manager) DefaultLanguage() string {
+	return m.defaultLang
 }
 
 // GetAvailableLanguages retorna a lista de idiomas disponíveis
@@ -222,7 +286,7 @@ func (m *Manager) GetLanguageInfo(code string) (*Locale, error) {
 
 	locale, exists := m.locales[code]
 	if !exists {
-		return nil, fmt.Errorf("idioma não encontrado: %s", code)
+		return nil, fmt.Errorf(m.translate("i18n.language_not_found"), code)
 	}
 
 	return locale, nil
@@ -231,7 +295,7 @@ func (m *Manager) GetLanguageInfo(code string) (*Locale, error) {
 // SetupCobraI18n configura o Cobra para usar internacionalização
 func (m *Manager) SetupCobraI18n(cmd *cobra.Command) {
 	// Adicionar flag para idioma
-	cmd.PersistentFlags().String("lang", "", "Idioma da interface (ex: pt-BR, en-US)")
+	cmd.PersistentFlags().String("lang", "", m.T("i18n.language_flag"))
 
 	// Hook para processar a flag de idioma
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
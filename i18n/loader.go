@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// TranslationsDirEnv aponta diretórios extras de tradução, separados como no PATH
+const TranslationsDirEnv = "CLI_TRANSLATIONS_DIR"
+
+// appDirName precisa acompanhar o cmdutils, que não pode ser importado aqui
+// porque depende deste pacote
+const appDirName = "gfcli"
+
+// EmbeddedSource identifica os idiomas que vêm embutidos no binário
+const EmbeddedSource = "embedded"
+
+// translationDir é um diretório de onde arquivos de tradução são lidos
+type translationDir struct {
+	path string
+	// explicit indica que o diretório foi pedido pelo usuário e deve existir
+	explicit bool
+}
+
+// TranslationDirs retorna os diretórios lidos depois das traduções embutidas,
+// do menos para o mais prioritário: o do sistema, o do usuário e os de
+// CLI_TRANSLATIONS_DIR
+func TranslationDirs() []string {
+	var paths []string
+	for _, dir := range translationDirs() {
+		paths = append(paths, dir.path)
+	}
+	return paths
+}
+
+func translationDirs() []translationDir {
+	var dirs []translationDir
+
+	switch runtime.GOOS {
+	case "windows":
+		if programData := os.Getenv("ProgramData"); programData != "" {
+			dirs = append(dirs, translationDir{path: filepath.Join(programData, appDirName, "translations")})
+		}
+	default:
+		dirs = append(dirs, translationDir{path: filepath.Join("/usr/share", appDirName, "translations")})
+	}
+
+	if base, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, translationDir{path: filepath.Join(base, appDirName, "translations")})
+	}
+
+	for _, path := range filepath.SplitList(os.Getenv(TranslationsDirEnv)) {
+		if path != "" {
+			dirs = append(dirs, translationDir{path: path, explicit: true})
+		}
+	}
+	return dirs
+}
+
+// loadDirs lê os diretórios de tradução, mesclando os arquivos encontrados
+// nos idiomas já carregados. Um arquivo inválido não impede os demais.
+func (m *Manager) loadDirs() error {
+	var errs []error
+	for _, dir := range translationDirs() {
+		if err := m.loadDir(dir); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loadDir lê os arquivos *.json de um diretório. Diretórios padrão
+// inexistentes são ignorados.
+func (m *Manager) loadDir(dir translationDir) error {
+	entries, err := os.ReadDir(dir.path)
+	if os.IsNotExist(err) {
+		if dir.explicit {
+			return fmt.Errorf(m.translate("i18n.translations_dir_not_found"), dir.path)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf(m.translate("i18n.error_listing_files"), err)
+	}
+
+	var errs []error
+	loaded := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir.path, entry.Name())
+		locale, err := readLocaleFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(m.translate("i18n.error_loading_file"), path, err))
+			continue
+		}
+		m.merge(locale)
+		loaded++
+	}
+
+	if loaded == 0 && len(errs) == 0 && dir.explicit {
+		return fmt.Errorf(m.translate("i18n.no_translation_files"), dir.path)
+	}
+	return errors.Join(errs...)
+}
+
+// readLocaleFile lê um arquivo de tradução do disco. Sem o campo "code", o
+// nome do arquivo é usado como código do idioma.
+func readLocaleFile(path string) (*Locale, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var locale Locale
+	if err := json.Unmarshal(data, &locale); err != nil {
+		return nil, err
+	}
+	if locale.Code == "" {
+		locale.Code = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+	locale.Sources = []string{path}
+	return &locale, nil
+}
+
+// merge adiciona um idioma ou sobrescreve as traduções de um idioma já
+// carregado. Traduções vazias não apagam as existentes.
+func (m *Manager) merge(locale *Locale) {
+	existing, exists := m.locales[locale.Code]
+	if !exists {
+		if locale.Translations == nil {
+			locale.Translations = make(map[string]string)
+		}
+		m.locales[locale.Code] = locale
+		return
+	}
+
+	if locale.Name != "" {
+		existing.Name = locale.Name
+	}
+	if locale.NativeName != "" {
+		existing.NativeName = locale.NativeName
+	}
+	for key, translation := range locale.Translations {
+		if translation != "" {
+			existing.Translations[key] = translation
+		}
+	}
+	existing.Sources = append(existing.Sources, locale.Sources...)
+}
@@ -0,0 +1,173 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Args passa placeholders nomeados para o T e o TN:
+//
+//	manager.T("cli.welcome", i18n.Args{"name": "João"}) // "Olá, {name}!"
+//
+// Chaves duplas, como {{ e }}, produzem as chaves literais.
+type Args map[string]interface{}
+
+// Formas de plural, na nomenclatura do CLDR. A chave "cli.deleted" no
+// código corresponde às traduções "cli.deleted.one", "cli.deleted.other" etc.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// pluralForms lista os sufixos de plural; apenas o "other" é obrigatório
+var pluralForms = []string{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+
+// namedPattern reconhece {nome} e as chaves escapadas {{ e }}
+var namedPattern = regexp.MustCompile(`\{\{|\}\}|\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
+// pluralCategory retorna a forma de plural de uma quantidade para o idioma,
+// seguindo as regras do CLDR para números inteiros
+func pluralCategory(code string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch baseLanguage(code) {
+	case "ja", "ko", "zh", "vi", "th", "id", "ms", "tr":
+		return PluralOther
+	case "pt", "fr":
+		if n == 0 || n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	case "ru", "uk", "be":
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return PluralOne
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	case "pl":
+		switch {
+		case n == 1:
+			return PluralOne
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	case "cs", "sk":
+		switch {
+		case n == 1:
+			return PluralOne
+		case n >= 2 && n <= 4:
+			return PluralFew
+		default:
+			return PluralOther
+		}
+	default:
+		if n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	}
+}
+
+// TN traduz uma mensagem com plural, escolhendo a forma pela quantidade e
+// pelas regras do idioma que tem a tradução. Uma forma "zero" explícita é
+// usada quando a quantidade é zero. A quantidade fica disponível como {count}
+// e os args seguem as mesmas regras do T.
+func (m *Manager) TN(key string, count int, args ...interface{}) string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	m.used.Store(key, true)
+	translation, exists := m.translatePlural(key, count)
+	if !exists {
+		return key
+	}
+
+	if len(args) == 0 {
+		return formatNamed(translation, Args{"count": count})
+	}
+	if named, ok := args[0].(Args); ok && len(args) == 1 {
+		merged := Args{"count": count}
+		for name, value := range named {
+			merged[name] = value
+		}
+		return formatNamed(translation, merged)
+	}
+	return fmt.Sprintf(translation, args...)
+}
+
+// translatePlural percorre a cadeia de idiomas procurando a forma de plural
+func (m *Manager) translatePlural(key string, count int) (string, bool) {
+	for _, locale := range m.chain {
+		forms := []string{pluralCategory(locale.Code, count), PluralOther}
+		if count == 0 {
+			forms = append([]string{PluralZero}, forms...)
+		}
+		for _, form := range forms {
+			if translation := locale.Translations[key+"."+form]; translation != "" {
+				return translation, true
+			}
+		}
+	}
+	return "", false
+}
+
+// format aplica os argumentos do T: um único Args usa placeholders nomeados
+// e os demais seguem o fmt.Sprintf
+func format(translation string, args []interface{}) string {
+	if len(args) == 0 {
+		return translation
+	}
+	if named, ok := args[0].(Args); ok && len(args) == 1 {
+		return formatNamed(translation, named)
+	}
+	return fmt.Sprintf(translation, args...)
+}
+
+// formatNamed substitui os placeholders {nome}. Nomes sem valor são mantidos
+// para que o erro fique visível na saída.
+func formatNamed(translation string, args Args) string {
+	return namedPattern.ReplaceAllStringFunc(translation, func(match string) string {
+		switch match {
+		case "{{":
+			return "{"
+		case "}}":
+			return "}"
+		}
+		if value, exists := args[match[1:len(match)-1]]; exists {
+			return fmt.Sprint(value)
+		}
+		return match
+	})
+}
+
+// namedPlaceholders retorna os placeholders {nome} de um texto
+func namedPlaceholders(text string) []string {
+	var names []string
+	for _, match := range namedPattern.FindAllStringSubmatch(text, -1) {
+		if match[1] != "" {
+			names = append(names, match[0])
+		}
+	}
+	return names
+}
+
+// pluralBase retorna a chave sem o sufixo de plural, se houver
+func pluralBase(key string) (string, bool) {
+	for _, form := range pluralForms {
+		if base, found := strings.CutSuffix(key, "."+form); found {
+			return base, true
+		}
+	}
+	return key, false
+}
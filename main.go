@@ -3,18 +3,23 @@ package main
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"os"
 	"runtime"
 	"runtime/debug"
 	"strings"
 
 	"gfcli/cmd"
+	cmdutils "gfcli/cmd_utils"
 	"gfcli/i18n"
+
+	"github.com/spf13/cobra"
 )
 
 var RawVersion string
 
+// rootCmd é mantido para que o panicRecover reconheça os atalhos das flags sensíveis
+var rootCmd *cobra.Command
+
 var version string = func() string {
 	if RawVersion == "" {
 		return getVCSInfo("v0.0.0")
@@ -76,46 +81,48 @@ func main() {
 	}
 	ctx := context.Background()
 
-	lang := getLang()
+	// A flag --lang tem prioridade sobre CLI_LANG, que tem prioridade sobre o idioma salvo
+	lang := getLangFromArgs(os.Args)
+	if lang == "" {
+		lang = getLang()
+	}
 	if lang == "" {
-		lang = getLangFromArgs(os.Args)
+		lang = cmdutils.SavedLanguage()
 	}
 
 	manager := i18n.GetInstance()
-	manager.SetLanguage(lang)
+	if lang != "" {
+		manager.SetLanguage(lang)
+	}
 
-	rootCmd := cmd.RootCmd(ctx, version, manager)
-	err := rootCmd.Execute()
+	rootCmd = cmd.RootCmd(ctx, version, manager)
+	err := cmd.Execute(rootCmd)
 	if err != nil {
-		os.Exit(1)
+		os.Exit(cmdutils.ExitCode(err))
 	}
 }
 
 func panicRecover() {
 	err := recover()
 	if err != nil {
-
-		Url := "https://github.com/geffersonFerraz/cli/issues/new"
-		args := strings.Join(os.Args, " ")
-
-		query := url.Values{}
-		query.Add("title", fmt.Sprintf("Error report at '%s'", args))
-		query.Add("body", fmt.Sprintf("Version: %s\nSO: %s / %s\nArgs: %s\nError: %s\n",
-			version,
-			runtime.GOOS,
-			runtime.GOARCH,
-			args,
-			err))
-		Url = Url + "?" + query.Encode()
+		redactor := cmdutils.NewRedactor(rootCmd, os.Args)
+		report := cmdutils.NewCrashReport(redactor, version, os.Args, err, debug.Stack())
+		Url := report.IssueURL()
 
 		manager := i18n.GetInstance()
 
+		crashFile, saveErr := cmdutils.SaveCrashReport(report)
+		if saveErr != nil {
+			crashFile = fmt.Sprintf("%s (%s)", manager.T("cli.crash.save_failed"), saveErr)
+		}
+
 		fmt.Fprintf(os.Stderr, `
 😔 %s
      %s: %s
      %s: %s / %s  
      %s: %s 
      %s: %s
+     %s: %s
 
 %s
 	%s
@@ -129,9 +136,11 @@ func panicRecover() {
 			runtime.GOOS,
 			runtime.GOARCH,
 			manager.T("cli.args"),
-			args,
+			report.CommandLine(),
 			manager.T("cli.error"),
-			err,
+			report.Error,
+			manager.T("cli.crash.file"),
+			crashFile,
 			manager.T("cli.panic_help"),
 			Url,
 			manager.T("cli.panic_thanks"))